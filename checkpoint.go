@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCheckpointInterval is how often the progress of a run is saved by default.
+const DefaultCheckpointInterval = 10 * time.Second
+
+// Checkpoint is a Recorder that keeps track of the lines of the targets file already compared, along with the tally
+// of their verdicts, saving them periodically to a file so an interrupted run can be resumed from where it stopped.
+type Checkpoint struct {
+	mu       sync.Mutex
+	file     string
+	interval time.Duration
+	now      func() time.Time
+	saved    time.Time
+	state    checkpointState
+	// done holds the lines after the offset already compared, since requests complete out of order.
+	done    map[int]bool
+	summary *Summary
+	// output is the file in which the results are written, whose size is saved along with the progress.
+	output *os.File
+	err    error
+}
+
+// checkpointState is the content of a checkpoint file.
+type checkpointState struct {
+	Path  string   `json:"path"`
+	Hosts []string `json:"hosts"`
+	// Offset is the number of lines from the beginning of the file which were all compared.
+	Offset int `json:"offset"`
+	// Done lists the lines after the offset which were compared as well.
+	Done    []int        `json:"done,omitempty"`
+	Summary summaryState `json:"summary"`
+	// Output is the size of the results file once the results of the lines compared were written.
+	Output int64 `json:"output,omitempty"`
+}
+
+// NewCheckpoint returns a Checkpoint of the run comparing the targets read from path against the given hosts,
+// which is saved to file at most once every interval.
+func NewCheckpoint(file, path string, hosts []string, interval time.Duration) *Checkpoint {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	return &Checkpoint{
+		file:     file,
+		interval: interval,
+		now:      time.Now,
+		state:    checkpointState{Path: path, Hosts: hosts},
+		done:     make(map[int]bool),
+		summary:  NewSummary(hosts),
+	}
+}
+
+// Resume continues from the progress saved in a checkpoint, which must have been taken comparing the same targets
+// against the same hosts.
+func (c *Checkpoint) Resume(r io.Reader) error {
+	var state checkpointState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return fmt.Errorf("invalid checkpoint: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if state.Path != c.state.Path || strings.Join(state.Hosts, " ") != strings.Join(c.state.Hosts, " ") {
+		return fmt.Errorf("checkpoint was taken comparing %s against %s", state.Path, strings.Join(state.Hosts, ", "))
+	}
+
+	c.state.Offset = state.Offset
+	c.state.Output = state.Output
+	for _, line := range state.Done {
+		c.done[line] = true
+	}
+	c.summary.restore(state.Summary)
+
+	return nil
+}
+
+// Record marks the line of the result as compared, saving the checkpoint if it was not saved during the last interval.
+func (c *Checkpoint) Record(r Result) {
+	if r.Line <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.summary.Record(r)
+	if r.Line == c.state.Offset+1 {
+		c.state.Offset++
+		for c.done[c.state.Offset+1] {
+			delete(c.done, c.state.Offset+1)
+			c.state.Offset++
+		}
+	} else if r.Line > c.state.Offset {
+		c.done[r.Line] = true
+	}
+
+	if c.now().Sub(c.saved) >= c.interval {
+		c.save()
+	}
+}
+
+// OpenOutput opens the file in which to write the results, whose size is saved along with the progress.
+// When resuming, the results written after the checkpoint was saved are discarded, since their lines are compared
+// again, and new results are written after the rest of them.
+func (c *Checkpoint) OpenOutput(path string) (*os.File, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Truncate(c.state.Output); err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	if _, err := f.Seek(c.state.Output, io.SeekStart); err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	c.output = f
+
+	return f, nil
+}
+
+// Done reports whether the given line was already compared.
+func (c *Checkpoint) Done(line int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return line <= c.state.Offset || c.done[line]
+}
+
+// Total returns the number of lines compared.
+func (c *Checkpoint) Total() int {
+	return c.summary.Total()
+}
+
+// RestoreSummary continues the tally of s from the one of the lines compared.
+func (c *Checkpoint) RestoreSummary(s *Summary) {
+	s.restore(c.summary.state())
+}
+
+// Save writes the checkpoint to its file, returning the first error found while saving it, if any.
+func (c *Checkpoint) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.save()
+
+	return c.err
+}
+
+// Err returns the first error found while saving the checkpoint, if any.
+func (c *Checkpoint) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+// save replaces the checkpoint file by writing a temporary one first, so it is never left half written.
+func (c *Checkpoint) save() {
+	c.saved = c.now()
+
+	state := c.state
+	state.Done = make([]int, 0, len(c.done))
+	for line := range c.done {
+		state.Done = append(state.Done, line)
+	}
+	sort.Ints(state.Done)
+	state.Summary = c.summary.state()
+
+	// Results are written before the line is recorded, so the file holds the results of every line compared.
+	var err error
+	if c.output != nil {
+		state.Output, err = c.output.Seek(0, io.SeekCurrent)
+	}
+
+	var b []byte
+	if err == nil {
+		b, err = json.Marshal(state)
+	}
+
+	if err == nil {
+		tmp := c.file + ".tmp"
+		if err = ioutil.WriteFile(tmp, b, 0600); err == nil {
+			err = os.Rename(tmp, c.file)
+		}
+	}
+
+	if err != nil && c.err == nil {
+		c.err = fmt.Errorf("could not save checkpoint: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomparator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	hosts := []string{"http://host1.com", "http://host2.com"}
+	file := filepath.Join(dir, "checkpoint.json")
+	c := NewCheckpoint(file, "urls.txt", hosts, DefaultCheckpointInterval)
+
+	// Requests complete out of order, so only the lines up to the first gap are covered by the offset.
+	for _, line := range []int{2, 1, 5, 3} {
+		c.Record(Result{Line: line, Verdict: VerdictEqual})
+	}
+	c.Record(Result{Line: 6, Verdict: VerdictBodyDiff, Comparisons: []Comparison{{Left: 0, Right: 1, Verdict: VerdictBodyDiff}}})
+	assert.NoError(t, c.Save())
+
+	f, err := os.Open(file)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	resumed := NewCheckpoint(file, "urls.txt", hosts, DefaultCheckpointInterval)
+	assert.NoError(t, resumed.Resume(f))
+
+	for line, done := range []bool{true, true, true, true, false, true, true, false} {
+		assert.Equal(t, done, resumed.Done(line), "line %d", line)
+	}
+
+	summary := NewSummary(hosts)
+	resumed.RestoreSummary(summary)
+	assert.Equal(t, 5, resumed.Total())
+	assert.Equal(t, 5, summary.Total())
+	assert.Equal(t, 1, summary.Mismatches())
+
+	resumed.Record(Result{Line: 4, Verdict: VerdictEqual})
+	assert.Equal(t, 6, resumed.state.Offset)
+	assert.False(t, resumed.Done(7))
+}
+
+func TestCheckpointOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomparator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	hosts := []string{"http://host1.com", "http://host2.com"}
+	file := filepath.Join(dir, "checkpoint.json")
+	output := filepath.Join(dir, "results.jsonl")
+	assert.NoError(t, ioutil.WriteFile(output, []byte("stale\n"), 0666))
+
+	c := NewCheckpoint(file, "urls.txt", hosts, DefaultCheckpointInterval)
+	f, err := c.OpenOutput(output)
+	assert.NoError(t, err)
+
+	w := NewResultWriter(f)
+	r := Result{Line: 1, RelURL: "/v1/cards/1", Verdict: VerdictEqual}
+	w.Record(r)
+	c.Record(r)
+	assert.NoError(t, c.Save())
+
+	// The result of a line written after the checkpoint was saved is discarded when resuming.
+	w.Record(Result{Line: 2, RelURL: "/v1/cards/2", Verdict: VerdictEqual})
+	assert.NoError(t, f.Close())
+
+	cf, err := os.Open(file)
+	assert.NoError(t, err)
+	defer cf.Close()
+
+	resumed := NewCheckpoint(file, "urls.txt", hosts, DefaultCheckpointInterval)
+	assert.NoError(t, resumed.Resume(cf))
+	f, err = resumed.OpenOutput(output)
+	assert.NoError(t, err)
+
+	w = NewResultWriter(f)
+	w.Record(Result{Line: 2, RelURL: "/v1/cards/2", Verdict: VerdictBodyDiff})
+	assert.NoError(t, f.Close())
+
+	b, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"rel_url":"/v1/cards/1","verdict":"equal"`)
+	assert.Contains(t, lines[1], `"rel_url":"/v1/cards/2","verdict":"body-diff"`)
+}
+
+func TestResumeCheckpointOfOtherHosts(t *testing.T) {
+	c := NewCheckpoint("checkpoint.json", "urls.txt", []string{"http://host1.com", "http://host3.com"}, DefaultCheckpointInterval)
+	path, _ := filepath.Abs("urls.txt")
+	err := c.Resume(strings.NewReader(`{"path":"` + path + `","hosts":["http://host1.com","http://host2.com"],"offset":3}`))
+
+	assert.EqualError(t, err, "checkpoint was taken comparing "+path+" against http://host1.com, http://host2.com")
+}
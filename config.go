@@ -0,0 +1,356 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// envPattern matches the references to environment variables in a configuration file. eg: ${API_TOKEN}
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Config holds the settings of a run read from a yaml or json file. Every setting maps to the flag of the same name,
+// which takes precedence over the value of the file when both are given.
+type Config struct {
+	Path    string            `yaml:"path"`
+	Format  string            `yaml:"format"`
+	Hosts   []string          `yaml:"hosts"`
+	Headers map[string]string `yaml:"headers"`
+	// HostHeaders, HostQuery and HostAuth are keyed by the index of the host or left and right.
+	HostHeaders        map[string]map[string]string `yaml:"host_headers"`
+	HostQuery          map[string]map[string]string `yaml:"host_query"`
+	HostAuth           map[string]string            `yaml:"host_auth"`
+	OAuth2             OAuth2Config                 `yaml:"oauth2"`
+	RateLimit          int                          `yaml:"ratelimit"`
+	Workers            int                          `yaml:"workers"`
+	Timeout            string                       `yaml:"timeout"`
+	Duration           string                       `yaml:"duration"`
+	Checkpoint         string                       `yaml:"checkpoint"`
+	CheckpointInterval string                       `yaml:"checkpoint_interval"`
+	StatusCodeOnly     bool                         `yaml:"status_code_only"`
+	Excludes           []string                     `yaml:"excludes"`
+	ExcludeFile        string                       `yaml:"exclude_file"`
+	Matchers           []string                     `yaml:"matchers"`
+	MatchKeys          []string                     `yaml:"match_keys"`
+	StrictOrder        bool                         `yaml:"strict_order"`
+	ArrayOrders        []string                     `yaml:"array_orders"`
+	Tolerances         []string                     `yaml:"tolerances"`
+	CompareHeaders     []string                     `yaml:"compare_headers"`
+	CompareAllHeaders  bool                         `yaml:"compare_all_headers"`
+	IgnoreHeaders      []string                     `yaml:"ignore_headers"`
+	SlowerThreshold    string                       `yaml:"slower_threshold"`
+	DetectNoise        bool                         `yaml:"detect_noise"`
+	CI                 bool                         `yaml:"ci"`
+	MaxMismatches      string                       `yaml:"max_mismatches"`
+	MaxErrors          string                       `yaml:"max_errors"`
+	Output             string                       `yaml:"output"`
+	Report             string                       `yaml:"report"`
+	JUnit              string                       `yaml:"junit"`
+	JUnitGroup         string                       `yaml:"junit_group"`
+	Listen             string                       `yaml:"listen"`
+	Snapshot           string                       `yaml:"snapshot"`
+	Left               string                       `yaml:"left"`
+	Right              string                       `yaml:"right"`
+}
+
+// OAuth2Config holds the client credentials from which to obtain a bearer token for every request.
+type OAuth2Config struct {
+	TokenURL     string   `yaml:"token_url"`
+	ClientID     string   `yaml:"client_id"`
+	ClientSecret string   `yaml:"client_secret"`
+	Scopes       []string `yaml:"scopes"`
+}
+
+// ReadConfig parses a yaml, or json, configuration replacing every ${VAR} with the value of the environment variable,
+// so secrets such as tokens do not need to be written in the file. References are replaced once the file is parsed,
+// so the values of the variables are taken as is, and every variable referenced must be set.
+func ReadConfig(r io.Reader) (*Config, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	// An empty file has no document to be decoded.
+	if doc.Kind == 0 {
+		return cfg, nil
+	}
+
+	if err := checkFields(&doc, reflect.TypeOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := interpolate(&doc); err != nil {
+		return nil, err
+	}
+
+	if err := doc.Decode(cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// checkFields returns an error for the first key of the node, or of its children, that is not a setting of the struct
+// type t, so a misspelled setting is rejected rather than silently ignored. It does what the KnownFields option of a
+// yaml.Decoder does, which only decodes bytes and so cannot be used to decode the interpolated node.
+func checkFields(n *yaml.Node, t reflect.Type) error {
+	switch {
+	case n.Kind == yaml.DocumentNode:
+		return checkFields(n.Content[0], t)
+	case n.Kind != yaml.MappingNode || t.Kind() != reflect.Struct:
+		return nil
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key := n.Content[i]
+		field, ok := yamlField(t, key.Value)
+		if !ok {
+			return fmt.Errorf("line %d: unknown setting %s", key.Line, key.Value)
+		}
+
+		if err := checkFields(n.Content[i+1], field.Type); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// yamlField returns the field of the struct type t decoded from the given key.
+func yamlField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Split(f.Tag.Get("yaml"), ",")[0] == key {
+			return f, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// interpolate replaces the references to environment variables in the scalar values of the node and its children.
+// Keys are left untouched.
+func interpolate(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range n.Content {
+			if err := interpolate(child); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(n.Content); i += 2 {
+			if err := interpolate(n.Content[i]); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !envPattern.MatchString(n.Value) {
+			return nil
+		}
+
+		var err error
+		n.Value = envPattern.ReplaceAllStringFunc(n.Value, func(ref string) string {
+			name := envPattern.FindStringSubmatch(ref)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok && err == nil {
+				err = fmt.Errorf("line %d: environment variable %s is not set", n.Line, name)
+			}
+
+			return value
+		})
+
+		// The type of a plain value is resolved again from the replaced one, so numbers can also be given by a variable.
+		if n.Style == 0 {
+			n.Tag = ""
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// flags returns the values of every setting given in the file by the name of the flag it maps to.
+func (cfg *Config) flags() map[string][]string {
+	flags := make(map[string][]string)
+	setString := func(name, value string) {
+		if value != "" {
+			flags[name] = []string{value}
+		}
+	}
+	setInt := func(name string, value int) {
+		if value != 0 {
+			flags[name] = []string{strconv.Itoa(value)}
+		}
+	}
+	setBool := func(name string, value bool) {
+		if value {
+			flags[name] = []string{"true"}
+		}
+	}
+	setSlice := func(name string, values []string) {
+		if len(values) > 0 {
+			flags[name] = values
+		}
+	}
+
+	setString("path", cfg.Path)
+	setString("format", cfg.Format)
+	setSlice("host", cfg.Hosts)
+	setString("oauth2-token-url", cfg.OAuth2.TokenURL)
+	setString("oauth2-client-id", cfg.OAuth2.ClientID)
+	setString("oauth2-client-secret", cfg.OAuth2.ClientSecret)
+	setSlice("oauth2-scope", cfg.OAuth2.Scopes)
+	setInt("ratelimit", cfg.RateLimit)
+	setInt("workers", cfg.Workers)
+	setString("timeout", cfg.Timeout)
+	setString("duration", cfg.Duration)
+	setString("checkpoint", cfg.Checkpoint)
+	setString("checkpoint-interval", cfg.CheckpointInterval)
+	setBool("status-code-only", cfg.StatusCodeOnly)
+	setSlice("exclude", cfg.Excludes)
+	setString("exclude-file", cfg.ExcludeFile)
+	setSlice("match", cfg.Matchers)
+	setSlice("match-key", cfg.MatchKeys)
+	setBool("strict-order", cfg.StrictOrder)
+	setSlice("array-order", cfg.ArrayOrders)
+	setSlice("tolerance", cfg.Tolerances)
+	setSlice("compare-header", cfg.CompareHeaders)
+	setBool("compare-all-headers", cfg.CompareAllHeaders)
+	setSlice("ignore-header", cfg.IgnoreHeaders)
+	setString("slower-threshold", cfg.SlowerThreshold)
+	setBool("detect-noise", cfg.DetectNoise)
+	setBool("ci", cfg.CI)
+	setString("max-mismatches", cfg.MaxMismatches)
+	setString("max-errors", cfg.MaxErrors)
+	setString("report", cfg.Report)
+	setString("junit", cfg.JUnit)
+	setString("junit-group", cfg.JUnitGroup)
+	setString("output", cfg.Output)
+	setString("listen", cfg.Listen)
+	setString("snapshot", cfg.Snapshot)
+	setString("left", cfg.Left)
+	setString("right", cfg.Right)
+
+	for _, name := range sortedNames(cfg.Headers) {
+		flags["header"] = append(flags["header"], name+":"+cfg.Headers[name])
+	}
+
+	for _, host := range sortedNames(cfg.HostHeaders) {
+		for _, name := range sortedNames(cfg.HostHeaders[host]) {
+			flags["host-header"] = append(flags["host-header"], host+":"+name+":"+cfg.HostHeaders[host][name])
+		}
+	}
+
+	for _, host := range sortedNames(cfg.HostQuery) {
+		for _, name := range sortedNames(cfg.HostQuery[host]) {
+			flags["host-query"] = append(flags["host-query"], host+":"+name+"="+cfg.HostQuery[host][name])
+		}
+	}
+
+	for _, host := range sortedNames(cfg.HostAuth) {
+		flags["host-auth"] = append(flags["host-auth"], host+":"+cfg.HostAuth[host])
+	}
+
+	return flags
+}
+
+// sortedNames returns the keys of a map of settings sorted, so they are applied in the same order on every run.
+func sortedNames(m interface{}) []string {
+	var names []string
+	switch t := m.(type) {
+	case map[string]string:
+		for name := range t {
+			names = append(names, name)
+		}
+	case map[string]map[string]string:
+		for name := range t {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// configFlag returns the flag which specifies the configuration file of every command.
+func configFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "config",
+		Usage: "specifies a yaml or json file from which to read the settings not given as flags, replacing ${VAR} with the environment variable VAR",
+	}
+}
+
+// applyConfig sets the flags of the command which were not given to the values of the configuration file, if any.
+// Settings for flags the command does not define are ignored, so the same file can be shared among commands.
+func applyConfig(c *cli.Context) error {
+	path := c.String("config")
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cfg, err := ReadConfig(f)
+	if err != nil {
+		return err
+	}
+
+	defined := c.Command.Flags
+	if c.Command.Name == "" {
+		defined = c.App.Flags
+	}
+
+	values := cfg.flags()
+	for _, flag := range defined {
+		names := flag.Names()
+		if isSet(c, names) {
+			continue
+		}
+
+		vs := values[names[0]]
+		if _, ok := flag.(*cli.StringSliceFlag); !ok && len(vs) > 1 {
+			// Commands with a single host, such as record, take the baseline one.
+			vs = vs[:1]
+		}
+
+		for _, value := range vs {
+			if err := c.Set(names[0], value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isSet reports whether a flag was given by any of its names.
+func isSet(c *cli.Context, names []string) bool {
+	for _, name := range names {
+		if c.IsSet(name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestReadConfig(t *testing.T) {
+	os.Setenv("GOMPARATOR_TEST_TOKEN", "secret")
+	defer os.Unsetenv("GOMPARATOR_TEST_TOKEN")
+
+	input := `
+hosts:
+  - http://host1.com
+  - http://host2.com
+headers:
+  X-Auth-Token: ${GOMPARATOR_TEST_TOKEN}
+workers: 4
+matchers:
+  - id=regex:^[0-9]+$
+`
+	cfg, err := ReadConfig(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://host1.com", "http://host2.com"}, cfg.Hosts)
+	assert.Equal(t, map[string]string{"X-Auth-Token": "secret"}, cfg.Headers)
+	assert.Equal(t, 4, cfg.Workers)
+	assert.Equal(t, []string{"id=regex:^[0-9]+$"}, cfg.Matchers)
+
+	cfg, err = ReadConfig(strings.NewReader(`{"ratelimit": 10, "excludes": ["date_created"]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 10, cfg.RateLimit)
+	assert.Equal(t, []string{"date_created"}, cfg.Excludes)
+
+	_, err = ReadConfig(strings.NewReader(`workers: many`))
+	assert.Error(t, err)
+
+	_, err = ReadConfig(strings.NewReader("workers: 4\nexclude:\n  - date_created\n"))
+	assert.EqualError(t, err, "line 2: unknown setting exclude")
+
+	_, err = ReadConfig(strings.NewReader("oauth2:\n  token_url: http://auth.com\n  client: gomparator\n"))
+	assert.EqualError(t, err, "line 3: unknown setting client")
+}
+
+func TestReadConfig_Interpolation(t *testing.T) {
+	os.Setenv("GOMPARATOR_TEST_TOKEN", "abc#def: ghi")
+	os.Setenv("GOMPARATOR_TEST_WORKERS", "8")
+	defer os.Unsetenv("GOMPARATOR_TEST_TOKEN")
+	defer os.Unsetenv("GOMPARATOR_TEST_WORKERS")
+
+	input := `
+headers:
+  Authorization: Bearer ${GOMPARATOR_TEST_TOKEN}
+  X-Quoted: "${GOMPARATOR_TEST_WORKERS}"
+excludes:
+  - ${GOMPARATOR_TEST_TOKEN}
+workers: ${GOMPARATOR_TEST_WORKERS}
+`
+	cfg, err := ReadConfig(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc#def: ghi", "X-Quoted": "8"}, cfg.Headers)
+	assert.Equal(t, []string{"abc#def: ghi"}, cfg.Excludes)
+	assert.Equal(t, 8, cfg.Workers)
+
+	_, err = ReadConfig(strings.NewReader(`{"headers": {"X-Auth-Token": "${GOMPARATOR_TEST_UNSET}"}}`))
+	assert.EqualError(t, err, "line 1: environment variable GOMPARATOR_TEST_UNSET is not set")
+}
+
+func TestConfigHostOptions(t *testing.T) {
+	input := `
+host_headers:
+  right: {Authorization: Bearer abc}
+  left: {X-Api-Key: xyz, Host: legacy.com}
+host_query:
+  right: {site: MLA}
+host_auth:
+  left: admin:secret
+`
+	cfg, err := ReadConfig(strings.NewReader(input))
+	assert.NoError(t, err)
+
+	flags := cfg.flags()
+	assert.Equal(t, []string{"left:Host:legacy.com", "left:X-Api-Key:xyz", "right:Authorization:Bearer abc"}, flags["host-header"])
+	assert.Equal(t, []string{"right:site=MLA"}, flags["host-query"])
+	assert.Equal(t, []string{"left:admin:secret"}, flags["host-auth"])
+}
+
+func TestApplyConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomparator")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "gomparator.yaml")
+	err = ioutil.WriteFile(path, []byte(`
+hosts: [http://host1.com, http://host2.com]
+headers: {Authorization: "Bearer abc"}
+workers: 4
+timeout: 2s
+`), 0644)
+	assert.NoError(t, err)
+
+	var hosts, headers []string
+	var workers int
+	var timeout time.Duration
+	app := newApp()
+	app.Action = func(c *cli.Context) error {
+		if err := applyConfig(c); err != nil {
+			return err
+		}
+
+		hosts, headers = c.StringSlice("host"), c.StringSlice("header")
+		workers, timeout = c.Int("workers"), c.Duration("timeout")
+
+		return nil
+	}
+
+	err = app.Run([]string{"gomparator", "--config", path, "--workers", "8", "-H", "X-Caller:test"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://host1.com", "http://host2.com"}, hosts)
+	assert.Equal(t, []string{"X-Caller:test"}, headers)
+	assert.Equal(t, 8, workers)
+	assert.Equal(t, 2*time.Second, timeout)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ExclusionRule removes the value at Path from both json before comparing them.
+// If the rule has a url pattern, it only applies to the rel urls matching it.
+type ExclusionRule struct {
+	Path       string
+	URLPattern string
+	pattern    *regexp.Regexp
+}
+
+// ParseExclusionRule parses a rule in the form of "path" or "url-pattern:path" where the url pattern is a rel url
+// starting with / in which * matches any sequence of characters. eg: /v1/cards*:results.#.created_at
+func ParseExclusionRule(s string) (ExclusionRule, error) {
+	s = strings.TrimSpace(s)
+	rule := ExclusionRule{Path: s}
+
+	if strings.HasPrefix(s, "/") {
+		index := strings.IndexRune(s, ':')
+		if index == -1 {
+			return ExclusionRule{}, fmt.Errorf("invalid exclusion rule %q: missing path after url pattern", s)
+		}
+		rule.URLPattern = s[:index]
+		rule.Path = s[index+1:]
+		rule.pattern = compileURLPattern(rule.URLPattern)
+	}
+
+	if rule.Path == "" {
+		return ExclusionRule{}, fmt.Errorf("invalid exclusion rule %q: empty path", s)
+	}
+
+	return rule, nil
+}
+
+// ReadExclusionRules parses one rule per line ignoring empty lines and comments starting with #.
+func ReadExclusionRules(r io.Reader) ([]ExclusionRule, error) {
+	var rules []ExclusionRule
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := ParseExclusionRule(line)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Matches reports whether the rule applies to the given rel url.
+func (e ExclusionRule) Matches(relURL string) bool {
+	return e.pattern == nil || e.pattern.MatchString(relURL)
+}
+
+// compileURLPattern converts a url pattern into an anchored regular expression in which * matches anything.
+func compileURLPattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExclusionRule(t *testing.T) {
+	rule, err := ParseExclusionRule("results.#.created_at")
+	assert.NoError(t, err)
+	assert.Equal(t, "results.#.created_at", rule.Path)
+	assert.True(t, rule.Matches("/v1/anything"))
+
+	rule, err = ParseExclusionRule("/v1/cards*:items.#.id")
+	assert.NoError(t, err)
+	assert.Equal(t, "items.#.id", rule.Path)
+	assert.Equal(t, "/v1/cards*", rule.URLPattern)
+	assert.True(t, rule.Matches("/v1/cards"))
+	assert.True(t, rule.Matches("/v1/cards/123?site=MLA"))
+	assert.False(t, rule.Matches("/v1/payments/123"))
+
+	_, err = ParseExclusionRule("/v1/cards")
+	assert.Error(t, err)
+
+	_, err = ParseExclusionRule("/v1/cards:")
+	assert.Error(t, err)
+}
+
+func TestReadExclusionRules(t *testing.T) {
+	input := `
+# volatile fields
+date_created
+/v1/cards?*:results.#.request_id
+`
+	rules, err := ReadExclusionRules(strings.NewReader(input))
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "date_created", rules[0].Path)
+	assert.True(t, rules[1].Matches("/v1/cards?id=1"))
+	assert.False(t, rules[1].Matches("/v1/cards/1"))
+}
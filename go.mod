@@ -13,4 +13,5 @@ require (
 	go.uber.org/atomic v1.6.0 // indirect
 	go.uber.org/ratelimit v0.1.0
 	gopkg.in/cheggaaa/pb.v1 v1.0.28
+	gopkg.in/yaml.v3 v3.0.1
 )
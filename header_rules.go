@@ -0,0 +1,112 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// volatileHeaders are the headers not compared by default when comparing all of them, since they are expected to differ
+// on every response or only describe the connection.
+var volatileHeaders = []string{
+	"Date",
+	"Content-Length",
+	"Connection",
+	"Keep-Alive",
+	"Transfer-Encoding",
+}
+
+// HeaderRules selects the response headers compared between hosts, either the ones in an allow list or every header,
+// always skipping the ones in the deny list. Differences are reported using the header name as path.
+type HeaderRules struct {
+	all   bool
+	allow []string
+	deny  map[string]bool
+}
+
+// NewHeaderRules returns the rules to compare the allowed headers, or every header not denied if all is set.
+// It returns nil if no header must be compared.
+func NewHeaderRules(all bool, allow, deny []string) *HeaderRules {
+	if !all && len(allow) == 0 {
+		return nil
+	}
+
+	r := &HeaderRules{all: all, deny: make(map[string]bool)}
+	for _, name := range deny {
+		r.deny[http.CanonicalHeaderKey(name)] = true
+	}
+
+	for _, name := range allow {
+		if name = http.CanonicalHeaderKey(name); !r.deny[name] {
+			r.allow = append(r.allow, name)
+		}
+	}
+
+	if all {
+		for _, name := range volatileHeaders {
+			r.deny[name] = true
+		}
+	}
+
+	return r
+}
+
+// Diff returns the differences between the selected headers of both responses sorted by name.
+func (r *HeaderRules) Diff(left, right http.Header) []Difference {
+	if r == nil {
+		return nil
+	}
+
+	var diffs []Difference
+	for _, name := range r.names(left, right) {
+		lv, lok := headerValue(left, name)
+		rv, rok := headerValue(right, name)
+
+		switch {
+		case lok && !rok:
+			diffs = append(diffs, Difference{Type: MissingRight, Path: name, Left: lv})
+		case !lok && rok:
+			diffs = append(diffs, Difference{Type: MissingLeft, Path: name, Right: rv})
+		case lv != rv:
+			diffs = append(diffs, Difference{Type: ValueMismatch, Path: name, Left: lv, Right: rv})
+		}
+	}
+
+	return diffs
+}
+
+// names returns the names of the headers to be compared, sorted.
+func (r *HeaderRules) names(left, right http.Header) []string {
+	set := make(map[string]bool)
+	for _, name := range r.allow {
+		set[name] = true
+	}
+
+	if r.all {
+		for _, h := range []http.Header{left, right} {
+			for name := range h {
+				if name = http.CanonicalHeaderKey(name); !r.deny[name] {
+					set[name] = true
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// headerValue returns every value of a header joined by a comma and whether it is present.
+func headerValue(h http.Header, name string) (string, bool) {
+	values, ok := h[name]
+	if !ok {
+		return "", false
+	}
+
+	return strings.Join(values, ", "), true
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderRules(t *testing.T) {
+	left := http.Header{
+		"Content-Type":  {"application/json"},
+		"Cache-Control": {"max-age=60"},
+		"Date":          {"Mon, 02 Jan 2006 15:04:05 GMT"},
+		"X-Request-Id":  {"1"},
+		"Vary":          {"Accept", "Origin"},
+	}
+	right := http.Header{
+		"Content-Type": {"application/json"},
+		"Date":         {"Mon, 02 Jan 2006 15:04:06 GMT"},
+		"X-Request-Id": {"2"},
+		"Vary":         {"Accept"},
+		"Location":     {"/v2/cards"},
+	}
+
+	assert.Nil(t, NewHeaderRules(false, nil, []string{"Date"}))
+	assert.Empty(t, NewHeaderRules(false, nil, nil).Diff(left, right))
+
+	rules := NewHeaderRules(false, []string{"content-type", "cache-control", "location"}, nil)
+	assert.Equal(t, []Difference{
+		{Type: MissingRight, Path: "Cache-Control", Left: "max-age=60"},
+		{Type: MissingLeft, Path: "Location", Right: "/v2/cards"},
+	}, rules.Diff(left, right))
+
+	rules = NewHeaderRules(true, nil, []string{"x-request-id"})
+	assert.Equal(t, []Difference{
+		{Type: MissingRight, Path: "Cache-Control", Left: "max-age=60"},
+		{Type: MissingLeft, Path: "Location", Right: "/v2/cards"},
+		{Type: ValueMismatch, Path: "Vary", Left: "Accept, Origin", Right: "Accept"},
+	}, rules.Diff(left, right))
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HostOptions holds the headers, query params and credentials sent only to one of the hosts,
+// such as a different api key or token for each of them.
+type HostOptions struct {
+	Headers  map[string]string
+	Query    url.Values
+	Username string
+	Password string
+}
+
+// ParseHostOptions returns the options of each of the given number of hosts from values in the form of "host:value",
+// where host is either the index of the host, starting from 0, or left and right for the first two of them.
+// Headers are given as "host:name:value", query params as "host:name=value" and credentials for basic authentication
+// as "host:username:password". eg: left:X-Auth-Token:abc, right:api_key=xyz or right:admin:secret
+func ParseHostOptions(n int, headers, query, credentials []string) ([]HostOptions, error) {
+	options := make([]HostOptions, n)
+
+	for _, h := range headers {
+		i, value, err := parseHostValue(h, n)
+		if err != nil {
+			return nil, err
+		}
+
+		kv := strings.SplitN(value, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid host header %q", h)
+		}
+
+		if options[i].Headers == nil {
+			options[i].Headers = make(map[string]string)
+		}
+		options[i].Headers[kv[0]] = kv[1]
+	}
+
+	for _, q := range query {
+		i, value, err := parseHostValue(q, n)
+		if err != nil {
+			return nil, err
+		}
+
+		kv := strings.SplitN(value, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid host query param %q", q)
+		}
+
+		if options[i].Query == nil {
+			options[i].Query = make(url.Values)
+		}
+		options[i].Query.Add(kv[0], kv[1])
+	}
+
+	for _, c := range credentials {
+		i, value, err := parseHostValue(c, n)
+		if err != nil {
+			return nil, err
+		}
+
+		kv := strings.SplitN(value, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid host credentials for host %d", i)
+		}
+		options[i].Username, options[i].Password = kv[0], kv[1]
+	}
+
+	return options, nil
+}
+
+// parseHostValue splits a value in the form of "host:value" returning the index of the host.
+func parseHostValue(s string, n int) (int, string, error) {
+	index := strings.IndexRune(s, ':')
+	if index == -1 {
+		return 0, "", fmt.Errorf("invalid host option %q: missing host", s)
+	}
+
+	i, err := parseHostLabel(s[:index])
+	if err != nil {
+		return 0, "", err
+	}
+
+	if i >= n {
+		return 0, "", fmt.Errorf("invalid host %q: only %d hosts were provided", s[:index], n)
+	}
+
+	return i, s[index+1:], nil
+}
+
+func parseHostLabel(label string) (int, error) {
+	switch label {
+	case "left":
+		return 0, nil
+	case "right":
+		return 1, nil
+	}
+
+	i, err := strconv.Atoi(label)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("invalid host %q: expected left, right or the index of the host", label)
+	}
+
+	return i, nil
+}
+
+// wrap returns a Fetcher that applies the options to every request before fetching it.
+func (o HostOptions) wrap(fetcher Fetcher) Fetcher {
+	if len(o.Headers) == 0 && len(o.Query) == 0 && o.Username == "" {
+		return fetcher
+	}
+
+	return &hostFetcher{fetcher: fetcher, options: o}
+}
+
+type hostFetcher struct {
+	fetcher Fetcher
+	options HostOptions
+}
+
+func (f *hostFetcher) Fetch(ctx context.Context, req Request) (*Response, error) {
+	headers := make(map[string]string, len(req.Headers)+len(f.options.Headers)+1)
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+
+	for k, v := range f.options.Headers {
+		headers[k] = v
+	}
+
+	if f.options.Username != "" {
+		credentials := f.options.Username + ":" + f.options.Password
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+	req.Headers = headers
+
+	if len(f.options.Query) > 0 {
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		q := u.Query()
+		for k, vs := range f.options.Query {
+			q[k] = vs
+		}
+		u.RawQuery = q.Encode()
+		req.URL = u.String()
+	}
+
+	return f.fetcher.Fetch(ctx, req)
+}
+
+// hostFetcherFor returns the Fetcher of the host at the given index, applying its options if any.
+func hostFetcherFor(fetcher Fetcher, options []HostOptions, i int) Fetcher {
+	if i >= len(options) {
+		return fetcher
+	}
+
+	return options[i].wrap(fetcher)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHostOptions(t *testing.T) {
+	options, err := ParseHostOptions(3,
+		[]string{"left:X-Api-Key:abc", "right:Authorization:Bearer x:y", "2:Host:candidate.com"},
+		[]string{"right:api_key=xyz"},
+		[]string{"left:admin:secret"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []HostOptions{
+		{Headers: map[string]string{"X-Api-Key": "abc"}, Username: "admin", Password: "secret"},
+		{Headers: map[string]string{"Authorization": "Bearer x:y"}, Query: url.Values{"api_key": {"xyz"}}},
+		{Headers: map[string]string{"Host": "candidate.com"}},
+	}, options)
+
+	for _, h := range []string{"X-Api-Key:abc", "center:X-Api-Key:abc", "2:X-Api-Key:abc", "left:X-Api-Key", "-1:X-Api-Key:abc"} {
+		_, err := ParseHostOptions(2, []string{h}, nil, nil)
+		assert.Error(t, err, h)
+	}
+
+	_, err = ParseHostOptions(2, nil, []string{"right:api_key"}, nil)
+	assert.Error(t, err)
+
+	_, err = ParseHostOptions(2, nil, nil, []string{"right:admin"})
+	assert.Error(t, err)
+}
+
+func TestProduceWithHostOptions(t *testing.T) {
+	left := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "abc", r.Header.Get("X-Api-Key"))
+			assert.Equal(t, "common", r.Header.Get("X-Caller"))
+			assert.Equal(t, "baseline.com", r.Host)
+			assert.Equal(t, "/v1/cards?id=1", r.URL.RequestURI())
+			_, _ = w.Write([]byte(`{}`))
+		}),
+	)
+	defer left.Close()
+
+	right := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "admin", username)
+			assert.Equal(t, "secret", password)
+			assert.Empty(t, r.Header.Get("X-Api-Key"))
+			assert.Equal(t, "xyz", r.URL.Query().Get("api_key"))
+			_, _ = w.Write([]byte(`{}`))
+		}),
+	)
+	defer right.Close()
+
+	options, err := ParseHostOptions(2, []string{"left:X-Api-Key:abc", "left:host:baseline.com"}, []string{"right:api_key=xyz"},
+		[]string{"right:admin:secret"})
+	assert.NoError(t, err)
+
+	p := &producer{headers: map[string]string{"X-Caller": "common"}, hosts: options, fetcher: NewHTTPClient()}
+	u := URLPair{RelURL: "/v1/cards?id=1"}
+	for _, host := range []string{left.URL, right.URL} {
+		v, _ := joinPath(host, u.RelURL)
+		u.URLs = append(u.URLs, URL{URL: v})
+	}
+
+	pair := p.produce(context.Background(), u)
+	assert.False(t, pair.HasErrors())
+	// The options of each host are not exposed in the urls being reported.
+	assert.Equal(t, "/v1/cards?id=1", pair.Hosts[1].URL.RequestURI())
+}
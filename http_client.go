@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptrace"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -16,15 +19,31 @@ const DefaultTimeout = 30 * time.Second
 // Defaults to no limit.
 const DefaultMaxBody = int64(-1)
 
+// Request describes an http call to be made by a Fetcher.
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	// Body is kept as a byte slice rather than an io.Reader so it can be replayed on every retry.
+	Body []byte
+}
+
 type Response struct {
 	Body       []byte
 	StatusCode int
+	Header     http.Header
+	// TTFB is the time to the first byte of the response since the request that got it started.
+	TTFB time.Duration
+	// Latency is the time it took the request that got the response to read it whole,
+	// excluding the failed attempts and the waits between them.
+	Latency time.Duration
 }
 
 type Client struct {
 	httpClient      *http.Client
 	retryableClient *retryablehttp.Client
 	maxBody         int64
+	tokens          *TokenSource
 }
 
 func NewHTTPClient(opts ...func(*Client)) *Client {
@@ -32,6 +51,7 @@ func NewHTTPClient(opts ...func(*Client)) *Client {
 
 	c.retryableClient = retryablehttp.NewClient()
 	c.retryableClient.Logger = nil
+	c.retryableClient.CheckRetry = retryPolicy
 	c.httpClient = c.retryableClient.HTTPClient
 	c.maxBody = DefaultMaxBody
 
@@ -55,16 +75,79 @@ func MaxBody(n int64) func(*Client) {
 	return func(a *Client) { a.maxBody = n }
 }
 
-func (c *Client) Fetch(url string, headers map[string]string) (*Response, error) {
+// NoRetries returns a functional option which makes a single attempt per request, returning whatever response
+// it gets, even one that would otherwise be retried such as a 500.
+func NoRetries() func(*Client) {
+	return func(c *Client) {
+		c.retryableClient.RetryMax = 0
+		c.retryableClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	}
+}
+
+// OAuth2 returns a functional option which authenticates every request with a bearer token obtained from the source.
+// If a host rejects the token with a 401, a new one is requested and the request is made once again.
+func OAuth2(tokens *TokenSource) func(*Client) {
+	return func(c *Client) { c.tokens = tokens }
+}
+
+func (c *Client) Fetch(ctx context.Context, req Request) (*Response, error) {
+	// Credentials given for the request, such as the ones of a proxied caller or of a host, are never replaced.
+	if c.tokens == nil || hasHeader(req.Headers, "Authorization") {
+		return c.fetch(ctx, req)
+	}
+
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.fetch(ctx, withBearer(req, token))
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	// The token may have been revoked before its expiry.
+	c.tokens.Invalidate(token)
+	if token, err = c.tokens.Token(ctx); err != nil {
+		return nil, err
+	}
+
+	return c.fetch(ctx, withBearer(req, token))
+}
+
+// withBearer returns a copy of the request with the given token in its Authorization header.
+func withBearer(req Request, token string) Request {
+	headers := make(map[string]string, len(req.Headers)+1)
+	for k, v := range req.Headers {
+		headers[k] = v
+	}
+	headers["Authorization"] = "Bearer " + token
+	req.Headers = headers
+
+	return req
+}
+
+func (c *Client) fetch(ctx context.Context, req Request) (*Response, error) {
 	res := Response{}
 
-	resp, err := c.get(url, headers)
+	// Each retry gets a new connection, so the time to first byte and the latency are measured from the last one.
+	var attempt, firstByte time.Time
+	trace := &httptrace.ClientTrace{
+		GetConn:              func(string) { attempt = time.Now() },
+		GotFirstResponseByte: func() { firstByte = time.Now() },
+	}
+
+	resp, err := c.do(httptrace.WithClientTrace(ctx, trace), req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	res.StatusCode = resp.StatusCode
+	res.Header = resp.Header
+	if !attempt.IsZero() && !firstByte.IsZero() {
+		res.TTFB = firstByte.Sub(attempt)
+	}
 
 	body := io.Reader(resp.Body)
 	if c.maxBody >= 0 {
@@ -81,13 +164,43 @@ func (c *Client) Fetch(url string, headers map[string]string) (*Response, error)
 		return nil, err
 	}
 
+	if !attempt.IsZero() {
+		res.Latency = time.Since(attempt)
+	}
+
 	return &res, nil
 }
 
-func (c *Client) get(url string, headers map[string]string) (*http.Response, error) {
-	req, _ := retryablehttp.NewRequest("GET", url, nil)
+func (c *Client) do(ctx context.Context, r Request) (*http.Response, error) {
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	// A nil body must not be converted to an empty byte slice since it would send a zero Content-Length.
+	var body interface{}
+	if r.Body != nil {
+		body = r.Body
+	}
+
+	// The retryable request rewinds the body before each attempt so every retry sends the same body.
+	req, err := retryablehttp.NewRequest(method, r.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	if !isIdempotentMethod(method) {
+		ctx = context.WithValue(ctx, singleAttemptKey{}, true)
+	}
+	req = req.WithContext(ctx)
+
+	for k, v := range r.Headers {
+		// The Host header is ignored by net/http, which takes it from the request instead.
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+
+			continue
+		}
 
-	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
 
@@ -98,3 +211,28 @@ func (c *Client) get(url string, headers map[string]string) (*http.Response, err
 
 	return resp, nil
 }
+
+// singleAttemptKey is the context key marking the requests that must not be retried.
+type singleAttemptKey struct{}
+
+// retryPolicy retries like the default policy except for the requests marked with singleAttemptKey, since a request
+// that failed with a 5xx or a connection error may have been processed by the host anyway, and a retried POST would
+// then be processed twice.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if retry && ctx.Value(singleAttemptKey{}) != nil {
+		return false, nil
+	}
+
+	return retry, checkErr
+}
+
+// isIdempotentMethod reports whether making a request with the method many times has the same effect as making it once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	default:
+		return isSafeMethod(method)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -23,7 +24,7 @@ func TestTimeout(t *testing.T) {
 	c.retryableClient.CheckRetry = func(ctx context.Context, resp *http.Response, err error) (bool, error) {
 		return false, nil
 	}
-	_, err := c.Fetch(server.URL, nil)
+	_, err := c.Fetch(context.Background(), Request{URL: server.URL})
 
 	assert.EqualError(t, err, fmt.Sprintf("Get \"%s\": context deadline exceeded (Client.Timeout exceeded while awaiting headers)", server.URL))
 }
@@ -43,7 +44,7 @@ func TestRetries(t *testing.T) {
 	)
 	defer server.Close()
 	c := NewHTTPClient(Timeout(10 * time.Millisecond))
-	res, _ := c.Fetch(server.URL, nil)
+	res, _ := c.Fetch(context.Background(), Request{URL: server.URL})
 	assert.Equal(t, 200, res.StatusCode)
 }
 
@@ -56,7 +57,7 @@ func TestRetryTimeout(t *testing.T) {
 	)
 	defer server.Close()
 	c := NewHTTPClient(Timeout(10 * time.Millisecond))
-	_, err := c.Fetch(server.URL, nil)
+	_, err := c.Fetch(context.Background(), Request{URL: server.URL})
 
 	assert.EqualError(t, err, fmt.Sprintf("GET %s giving up after 5 attempts", server.URL))
 }
@@ -72,7 +73,7 @@ func TestResponseBodyCapture(t *testing.T) {
 	defer server.Close()
 
 	c := NewHTTPClient()
-	res, _ := c.Fetch(server.URL, nil)
+	res, _ := c.Fetch(context.Background(), Request{URL: server.URL})
 
 	assert.Equal(t, want, res.Body)
 }
@@ -86,7 +87,118 @@ func TestStatusCode(t *testing.T) {
 	)
 	defer server.Close()
 	c := NewHTTPClient()
-	res, _ := c.Fetch(server.URL, nil)
+	res, _ := c.Fetch(context.Background(), Request{URL: server.URL})
 
 	assert.Equal(t, 400, res.StatusCode)
 }
+
+func TestRequestBodyIsReplayedOnRetries(t *testing.T) {
+	t.Parallel()
+	var bodies []string
+	var methods []string
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+			methods = append(methods, r.Method)
+			if len(bodies) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}),
+	)
+	defer server.Close()
+	c := NewHTTPClient()
+	c.retryableClient.RetryWaitMin = time.Millisecond
+	c.retryableClient.RetryWaitMax = time.Millisecond
+	res, err := c.Fetch(context.Background(), Request{Method: http.MethodPut, URL: server.URL, Body: []byte(`{"amount":10}`)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 201, res.StatusCode)
+	assert.Equal(t, []string{`{"amount":10}`, `{"amount":10}`, `{"amount":10}`}, bodies)
+	assert.Equal(t, []string{"PUT", "PUT", "PUT"}, methods)
+}
+
+func TestNonIdempotentRequestIsNotRetried(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}),
+	)
+	defer server.Close()
+	c := NewHTTPClient()
+	c.retryableClient.RetryWaitMin = time.Millisecond
+	c.retryableClient.RetryWaitMax = time.Millisecond
+	res, err := c.Fetch(context.Background(), Request{Method: http.MethodPost, URL: server.URL, Body: []byte(`{"amount":10}`)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 503, res.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTTFB(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-time.After(20 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	defer server.Close()
+
+	c := NewHTTPClient()
+	res, err := c.Fetch(context.Background(), Request{URL: server.URL})
+	assert.NoError(t, err)
+	assert.True(t, res.TTFB >= 20*time.Millisecond, res.TTFB)
+}
+
+func TestLatencyExcludesRetries(t *testing.T) {
+	t.Parallel()
+	var count int
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count++
+			if count < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	defer server.Close()
+
+	c := NewHTTPClient()
+	c.retryableClient.RetryWaitMin = 100 * time.Millisecond
+	c.retryableClient.RetryWaitMax = 100 * time.Millisecond
+
+	start := time.Now()
+	res, err := c.Fetch(context.Background(), Request{URL: server.URL})
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.True(t, elapsed >= 200*time.Millisecond, elapsed)
+	assert.True(t, res.Latency > 0 && res.Latency < 100*time.Millisecond, res.Latency)
+}
+
+func TestFetchWithCancel(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}),
+	)
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := NewHTTPClient().Fetch(ctx, Request{URL: server.URL})
+
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+}
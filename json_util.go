@@ -1,13 +1,284 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+// DiffType describes how two Body-encoded values differ at a given path.
+type DiffType int
+
+const (
+	// ValueMismatch means both values are present but differ.
+	ValueMismatch DiffType = iota
+	// MissingLeft means the value is only present on the right.
+	MissingLeft
+	// MissingRight means the value is only present on the left.
+	MissingRight
+	// InvalidLeft means the value on the left does not satisfy a matcher rule.
+	InvalidLeft
+	// InvalidRight means the value on the right does not satisfy a matcher rule.
+	InvalidRight
+)
+
+func (t DiffType) String() string {
+	switch t {
+	case MissingLeft:
+		return "missing-left"
+	case MissingRight:
+		return "missing-right"
+	case InvalidLeft:
+		return "invalid-left"
+	case InvalidRight:
+		return "invalid-right"
+	default:
+		return "mismatch"
+	}
+}
+
+// Difference is a single difference found between 2 Body-encoded values.
+// Path is a series of keys separated by a dot in which array elements are identified by their index, eg: items.0.id,
+// unlike the paths taken by Remove, in which # matches every element. normalizePath converts the former to the latter.
+type Difference struct {
+	Type DiffType
+	Path string
+	// RightPath is the path of the value on the right when it is not Path, as happens inside array elements paired
+	// with an element at another index.
+	RightPath string
+	Left      interface{}
+	Right     interface{}
+}
+
+// rightPath returns the path of the value on the right.
+func (d Difference) rightPath() string {
+	if d.RightPath != "" {
+		return d.RightPath
+	}
+
+	return d.Path
+}
+
+// Comparator compares Body-encoded data ignoring the order of keys and, unless configured otherwise,
+// the order of array elements.
+// Rules are registered by the normalized path of the array elements, eg: items.# for the elements of items.
+type Comparator struct {
+	// keys holds the key used to pair the elements of an array.
+	keys map[string]string
+	// orders holds whether the elements of an array must be compared in order.
+	orders map[string]bool
+	// strictOrder is whether arrays with no order rule are compared in order.
+	strictOrder bool
+	// tolerances holds the tolerance allowed when comparing the numbers at a given path.
+	tolerances map[string]tolerance
+	// defaultTolerance applies to the numbers at the paths with no tolerance.
+	defaultTolerance *tolerance
+}
+
+// tolerance is the maximum difference allowed between two numbers, either absolute or relative to the largest one.
+type tolerance struct {
+	value    float64
+	relative bool
+}
+
+var defaultComparator = NewComparator()
+
+func NewComparator(opts ...func(*Comparator)) *Comparator {
+	c := Comparator{
+		keys:       make(map[string]string),
+		orders:     make(map[string]bool),
+		tolerances: make(map[string]tolerance),
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &c
+}
+
+// MatchKey returns a functional option which pairs the elements of the array at the given path by the value of key,
+// so the differences found inside two elements with the same key are reported precisely.
+// The path refers to the elements of the array, eg: items.# matches the elements of items.
+func MatchKey(path, key string) func(*Comparator) {
+	return func(c *Comparator) {
+		c.keys[ruleElementsPath(path)] = key
+	}
+}
+
+// StrictOrder returns a functional option which compares every array in order, unless overridden by ArrayOrder.
+func StrictOrder() func(*Comparator) {
+	return func(c *Comparator) {
+		c.strictOrder = true
+	}
+}
+
+// ArrayOrder returns a functional option which sets whether the array at the given path is compared in order.
+func ArrayOrder(path string, ordered bool) func(*Comparator) {
+	return func(c *Comparator) {
+		c.orders[ruleElementsPath(path)] = ordered
+	}
+}
+
+// Tolerance returns a functional option which allows the numbers at the given path to differ up to value,
+// either as an absolute difference or, if relative is set, as a fraction of the largest number.
+// An empty path sets the tolerance for every number.
+func Tolerance(path string, value float64, relative bool) func(*Comparator) {
+	return func(c *Comparator) {
+		t := tolerance{value: value, relative: relative}
+		if path == "" {
+			c.defaultTolerance = &t
+
+			return
+		}
+		c.tolerances[normalizePath(path)] = t
+	}
+}
+
+// ParseTolerance parses a tolerance in the form of "value" or "path=value", where value is either an absolute
+// difference or a percentage relative to the largest number. eg: 0.000001, price=0.01 or items.#.price=1%
+func ParseTolerance(s string) (func(*Comparator), error) {
+	path, value := "", s
+	if index := strings.LastIndexByte(s, '='); index != -1 {
+		path, value = s[:index], s[index+1:]
+		if path == "" {
+			return nil, fmt.Errorf("invalid tolerance %q", s)
+		}
+	}
+
+	relative := strings.HasSuffix(value, "%")
+	v, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || v < 0 {
+		return nil, fmt.Errorf("invalid tolerance %q", s)
+	}
+
+	if relative {
+		v /= 100
+	}
+
+	return Tolerance(path, v, relative), nil
+}
+
+// ParseMatchKey parses a rule in the form of "path=key". eg: results.#=id
+func ParseMatchKey(s string) (func(*Comparator), error) {
+	path, key, err := parseRule(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match key %q", s)
+	}
+
+	return MatchKey(path, key), nil
+}
+
+// ParseArrayOrder parses a rule in the form of "path=ordered" or "path=unordered". eg: results=ordered
+func ParseArrayOrder(s string) (func(*Comparator), error) {
+	path, order, err := parseRule(s)
+	if err != nil || (order != "ordered" && order != "unordered") {
+		return nil, fmt.Errorf("invalid array order %q", s)
+	}
+
+	return ArrayOrder(path, order == "ordered"), nil
+}
+
+// parseRule splits a rule in the form of "path=value".
+func parseRule(s string) (path, value string, err error) {
+	index := strings.LastIndexByte(s, '=')
+	if index <= 0 || index == len(s)-1 {
+		return "", "", fmt.Errorf("invalid rule %q", s)
+	}
+
+	return s[:index], s[index+1:], nil
+}
+
+// elementsPath returns the normalized path of the elements of the array at the given path.
+// eg: matrix.0 returns matrix.#.#
+func elementsPath(path string) string {
+	return joinKey(normalizePath(path), "#")
+}
+
+// ruleElementsPath returns the normalized path of the elements of the array a rule refers to.
+// It accepts either the path of the array or the one of its elements, eg: both items and items.# return items.#
+func ruleElementsPath(path string) string {
+	path = normalizePath(path)
+	if path == "#" || strings.HasSuffix(path, ".#") {
+		return path
+	}
+
+	return joinKey(path, "#")
+}
+
+// fuzzy reports whether values with a different canonical form may be equal.
+func (c *Comparator) fuzzy() bool {
+	return c.defaultTolerance != nil || len(c.tolerances) > 0
+}
+
+// equalNumbers compares two numbers exactly and, if they differ, using the tolerance for the given normalized path.
+func (c *Comparator) equalNumbers(path string, x, y json.Number) bool {
+	if x == y {
+		return true
+	}
+
+	rx, okx := new(big.Rat).SetString(string(x))
+	ry, oky := new(big.Rat).SetString(string(y))
+	if !okx || !oky {
+		return false
+	}
+
+	if rx.Cmp(ry) == 0 {
+		return true
+	}
+
+	t, ok := c.tolerances[path]
+	if !ok {
+		if c.defaultTolerance == nil {
+			return false
+		}
+		t = *c.defaultTolerance
+	}
+
+	fx, _ := rx.Float64()
+	fy, _ := ry.Float64()
+	delta := math.Abs(fx - fy)
+	if t.relative {
+		return delta <= t.value*math.Max(math.Abs(fx), math.Abs(fy))
+	}
+
+	return delta <= t.value
+}
+
+// ordered reports whether the elements at the given normalized path must be compared in order.
+func (c *Comparator) ordered(elements string) bool {
+	if ordered, ok := c.orders[elements]; ok {
+		return ordered
+	}
+
+	return c.strictOrder
+}
+
 // Equal checks equality between 2 Body-encoded data.
 func Equal(vx, vy interface{}) bool {
+	return defaultComparator.Equal(vx, vy)
+}
+
+// Diff returns the list of differences between 2 Body-encoded data.
+func Diff(vx, vy interface{}) []Difference {
+	return defaultComparator.Diff(vx, vy)
+}
+
+// Equal checks equality between 2 Body-encoded data.
+func (c *Comparator) Equal(vx, vy interface{}) bool {
+	return c.equal("", vx, vy)
+}
+
+// equal checks equality between 2 Body-encoded data at the given normalized path.
+func (c *Comparator) equal(path string, vx, vy interface{}) bool {
 	if reflect.TypeOf(vx) != reflect.TypeOf(vy) {
 		return false
 	}
@@ -21,13 +292,12 @@ func Equal(vx, vy interface{}) bool {
 		}
 
 		for k, v := range x {
-			val2 := y[k]
-
-			if (v == nil) != (val2 == nil) {
+			val2, ok := y[k]
+			if !ok {
 				return false
 			}
 
-			if !Equal(v, val2) {
+			if !c.equal(joinKey(path, k), v, val2) {
 				return false
 			}
 		}
@@ -40,25 +310,367 @@ func Equal(vx, vy interface{}) bool {
 			return false
 		}
 
-		var matches int
-		flagged := make([]bool, len(y))
-		for _, v := range x {
-			for i, v2 := range y {
-				if Equal(v, v2) && !flagged[i] {
-					matches++
-					flagged[i] = true
-
-					break
+		elements := joinKey(path, "#")
+		if c.ordered(elements) {
+			for i := range x {
+				if !c.equal(elements, x[i], y[i]) {
+					return false
 				}
 			}
+
+			return true
+		}
+
+		// Elements are indexed by their canonical form which makes the comparison linear instead of quadratic.
+		index := make(map[string][]int, len(x))
+		for i, v := range x {
+			k := c.canonical(elements, v)
+			index[k] = append(index[k], i)
+		}
+
+		var unmatched []int
+		for j, v := range y {
+			k := c.canonical(elements, v)
+			if is := index[k]; len(is) > 0 {
+				index[k] = is[1:]
+
+				continue
+			}
+
+			if !c.fuzzy() {
+				return false
+			}
+			unmatched = append(unmatched, j)
 		}
 
-		return matches == len(x)
+		if len(unmatched) == 0 {
+			return true
+		}
+
+		// Elements that are only equal within a tolerance are compared one by one.
+		var remaining []int
+		for _, is := range index {
+			remaining = append(remaining, is...)
+		}
+
+		return c.matchAll(elements, x, y, remaining, unmatched)
+	case json.Number:
+		return c.equalNumbers(path, x, vy.(json.Number))
 	default:
 		return vx == vy
 	}
 }
 
+// matchAll reports whether every element of x at the given indexes has an equal element of y at the given indexes.
+func (c *Comparator) matchAll(elements string, x, y []interface{}, is, js []int) bool {
+	used := make([]bool, len(js))
+	for _, i := range is {
+		found := false
+		for n, j := range js {
+			if !used[n] && c.equal(elements, x[i], y[j]) {
+				used[n] = true
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Diff returns the list of differences between 2 Body-encoded data.
+// Array elements are paired first with an equal element, then by their key if there is a MatchKey rule for the
+// array and otherwise in order of appearance. Differences inside paired elements are reported using the index
+// of the left element, along with the path of the right element when its index is a different one.
+func (c *Comparator) Diff(vx, vy interface{}) []Difference {
+	return c.diff("", "", vx, vy, nil)
+}
+
+// diff compares the values found at path on the left and at right on the right, which only differ inside array
+// elements paired with an element at another index.
+func (c *Comparator) diff(path, right string, vx, vy interface{}, acc []Difference) []Difference {
+	if reflect.TypeOf(vx) != reflect.TypeOf(vy) {
+		return append(acc, newDifference(ValueMismatch, path, right, vx, vy))
+	}
+
+	switch x := vx.(type) {
+	case map[string]interface{}:
+		y := vy.(map[string]interface{})
+
+		for _, k := range sortedKeys(x) {
+			v2, ok := y[k]
+			if !ok {
+				acc = append(acc, Difference{Type: MissingRight, Path: joinKey(path, k), Left: x[k]})
+
+				continue
+			}
+			acc = c.diff(joinKey(path, k), joinKey(right, k), x[k], v2, acc)
+		}
+
+		for _, k := range sortedKeys(y) {
+			if _, ok := x[k]; !ok {
+				acc = append(acc, newDifference(MissingLeft, joinKey(path, k), joinKey(right, k), nil, y[k]))
+			}
+		}
+
+		return acc
+	case []interface{}:
+		y := vy.([]interface{})
+		if c.ordered(elementsPath(path)) {
+			return c.diffOrdered(path, right, x, y, acc)
+		}
+
+		pairs, paired := c.pair(path, x, y)
+
+		for i, j := range pairs {
+			switch {
+			case j == -1:
+				acc = append(acc, Difference{Type: MissingRight, Path: joinKey(path, strconv.Itoa(i)), Left: x[i]})
+			case j >= 0:
+				acc = c.diff(joinKey(path, strconv.Itoa(i)), joinKey(right, strconv.Itoa(j)), x[i], y[j], acc)
+			}
+		}
+
+		for j, ok := range paired {
+			if !ok {
+				acc = append(acc, newDifference(MissingLeft, joinKey(path, strconv.Itoa(j)), joinKey(right, strconv.Itoa(j)), nil, y[j]))
+			}
+		}
+
+		return acc
+	case json.Number:
+		if !c.equalNumbers(normalizePath(path), x, vy.(json.Number)) {
+			acc = append(acc, newDifference(ValueMismatch, path, right, vx, vy))
+		}
+
+		return acc
+	default:
+		if vx != vy {
+			acc = append(acc, newDifference(ValueMismatch, path, right, vx, vy))
+		}
+
+		return acc
+	}
+}
+
+// diffOrdered compares the elements of both arrays by their position.
+func (c *Comparator) diffOrdered(path, right string, x, y []interface{}, acc []Difference) []Difference {
+	for i := 0; i < len(x) || i < len(y); i++ {
+		p, rp := joinKey(path, strconv.Itoa(i)), joinKey(right, strconv.Itoa(i))
+		switch {
+		case i >= len(y):
+			acc = append(acc, Difference{Type: MissingRight, Path: p, Left: x[i]})
+		case i >= len(x):
+			acc = append(acc, newDifference(MissingLeft, p, rp, nil, y[i]))
+		default:
+			acc = c.diff(p, rp, x[i], y[i], acc)
+		}
+	}
+
+	return acc
+}
+
+// newDifference returns a Difference found at path on the left and at right on the right,
+// setting RightPath only if both paths differ.
+func newDifference(t DiffType, path, right string, left, rightValue interface{}) Difference {
+	d := Difference{Type: t, Path: path, Left: left, Right: rightValue}
+	if right != path {
+		d.RightPath = right
+	}
+
+	return d
+}
+
+// equalPair marks an element of the left array that has an equal element on the right.
+const equalPair = -2
+
+// pair returns, for every element of x, the index of the element of y it is paired with, equalPair if
+// there is an equal element or -1 if it has no counterpart, along with the elements of y already used.
+func (c *Comparator) pair(path string, x, y []interface{}) (pairs []int, used []bool) {
+	pairs = make([]int, len(x))
+	used = make([]bool, len(y))
+	elements := elementsPath(path)
+
+	index := make(map[string][]int, len(y))
+	for j, v := range y {
+		k := c.canonical(elements, v)
+		index[k] = append(index[k], j)
+	}
+
+	var unmatched []int
+	for i, v := range x {
+		k := c.canonical(elements, v)
+		if js := index[k]; len(js) > 0 {
+			pairs[i] = equalPair
+			used[js[0]] = true
+			index[k] = js[1:]
+
+			continue
+		}
+		pairs[i] = -1
+		unmatched = append(unmatched, i)
+	}
+
+	if c.fuzzy() {
+		unmatched = c.pairEqual(elements, x, y, unmatched, pairs, used)
+	}
+
+	if key, ok := c.keys[elements]; ok {
+		byKey := make(map[string][]int)
+		for j, v := range y {
+			if kv, ok := lookup(v, key); ok && !used[j] {
+				k := c.canonical(elements, kv)
+				byKey[k] = append(byKey[k], j)
+			}
+		}
+
+		for _, i := range unmatched {
+			kv, ok := lookup(x[i], key)
+			if !ok {
+				continue
+			}
+
+			k := c.canonical(elements, kv)
+			if js := byKey[k]; len(js) > 0 {
+				pairs[i] = js[0]
+				used[js[0]] = true
+				byKey[k] = js[1:]
+			}
+		}
+
+		return pairs, used
+	}
+
+	j := 0
+	for _, i := range unmatched {
+		for j < len(y) && used[j] {
+			j++
+		}
+
+		if j == len(y) {
+			break
+		}
+		pairs[i] = j
+		used[j] = true
+	}
+
+	return pairs, used
+}
+
+// pairEqual pairs the unmatched elements of x that are equal to an unused element of y, which may happen
+// without having the same canonical form, and returns the ones still unmatched.
+func (c *Comparator) pairEqual(elements string, x, y []interface{}, unmatched, pairs []int, used []bool) []int {
+	var remaining []int
+	for _, i := range unmatched {
+		found := false
+		for j := range y {
+			if !used[j] && c.equal(elements, x[i], y[j]) {
+				pairs[i] = equalPair
+				used[j] = true
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			remaining = append(remaining, i)
+		}
+	}
+
+	return remaining
+}
+
+// lookup returns the value at the given path of keys separated by a dot.
+func lookup(v interface{}, path string) (interface{}, bool) {
+	for _, k := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		if v, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+// canonical returns a representation of a Body-encoded value at the given normalized path that is the same
+// for equal values, regardless of the order of their keys and unordered array elements.
+func (c *Comparator) canonical(path string, v interface{}) string {
+	var b strings.Builder
+	c.writeCanonical(&b, path, v)
+
+	return b.String()
+}
+
+func (c *Comparator) writeCanonical(b *strings.Builder, path string, v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		b.WriteByte('{')
+		for i, k := range sortedKeys(t) {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(k))
+			b.WriteByte(':')
+			c.writeCanonical(b, joinKey(path, k), t[k])
+		}
+		b.WriteByte('}')
+	case []interface{}:
+		path = joinKey(path, "#")
+		elements := make([]string, len(t))
+		for i, e := range t {
+			elements[i] = c.canonical(path, e)
+		}
+
+		if !c.ordered(path) {
+			sort.Strings(elements)
+		}
+
+		b.WriteByte('[')
+		b.WriteString(strings.Join(elements, ","))
+		b.WriteByte(']')
+	case string:
+		b.WriteString(strconv.Quote(t))
+	case json.Number:
+		b.WriteString(canonicalNumber(t))
+	case float64:
+		b.WriteString(strconv.FormatFloat(t, 'g', -1, 64))
+	case bool:
+		b.WriteString(strconv.FormatBool(t))
+	case nil:
+		b.WriteString("null")
+	default:
+		fmt.Fprintf(b, "%v", t)
+	}
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 func Remove(i interface{}, path string) {
 	if path == "" {
 		return
@@ -96,14 +708,110 @@ func Remove(i interface{}, path string) {
 	}
 }
 
+// Replace sets every value found at the given path to the one returned by fn, which is called with the path
+// of the value, using the element index for arrays, and the value itself.
+func Replace(i interface{}, path string, fn func(path string, v interface{}) interface{}) {
+	replace(i, path, "", fn)
+}
+
+func replace(i interface{}, path, prefix string, fn func(path string, v interface{}) interface{}) {
+	if path == "" {
+		return
+	}
+
+	current, next := path, ""
+	if index := strings.IndexRune(path, '.'); index != -1 {
+		current, next = path[:index], path[index+1:]
+	}
+
+	switch t := i.(type) {
+	case map[string]interface{}:
+		v, ok := t[current]
+		if !ok {
+			return
+		}
+
+		if next == "" {
+			t[current] = fn(joinKey(prefix, current), v)
+
+			return
+		}
+		replace(v, next, joinKey(prefix, current), fn)
+	case []interface{}:
+		if current != "#" {
+			return
+		}
+
+		for index, v := range t {
+			p := joinKey(prefix, strconv.Itoa(index))
+			if next == "" {
+				t[index] = fn(p, v)
+
+				continue
+			}
+			replace(v, next, p, fn)
+		}
+	}
+}
+
+// canonicalNumber returns the same representation for equal numbers written differently, such as 10 and 10.0.
+func canonicalNumber(n json.Number) string {
+	if _, err := strconv.ParseInt(string(n), 10, 64); err == nil && n != "-0" {
+		return string(n)
+	}
+
+	r, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return string(n)
+	}
+
+	return r.RatString()
+}
+
+// normalizePath replaces the array indexes of a path with # so it matches every element.
+func normalizePath(path string) string {
+	if path == "" {
+		return path
+	}
+
+	keys := strings.Split(path, ".")
+	for i, k := range keys {
+		if isIndex(k) {
+			keys[i] = "#"
+		}
+	}
+
+	return strings.Join(keys, ".")
+}
+
+func isIndex(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for _, r := range key {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Unmarshal parses the Body-encoded data into an interface{}.
+// Numbers are decoded as json.Number so they do not lose precision.
 func Unmarshal(b []byte) (interface{}, error) {
 	var j interface{}
 
-	err := json.Unmarshal(b, &j)
-	if err != nil {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&j); err != nil {
 		return nil, err
 	}
 
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+
 	return j, nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,6 +38,12 @@ func TestEqual(t *testing.T) {
 			b1:      []byte(`{"FirstName":"Alan"}`),
 			b2:      []byte(`{"FirstName":"Galileo"}`),
 		},
+		{
+			name:    "different keys with null values",
+			isEqual: false,
+			b1:      []byte(`{"a":null}`),
+			b2:      []byte(`{"b":null}`),
+		},
 		{
 			name:    "equal first name",
 			isEqual: true,
@@ -182,6 +189,7 @@ func TestEqual(t *testing.T) {
 			j1, _ := Unmarshal(test.b1)
 			j2, _ := Unmarshal(test.b2)
 			assert.Equal(t, test.isEqual, Equal(j1, j2))
+			assert.Equal(t, test.isEqual, len(Diff(j1, j2)) == 0)
 		})
 
 	}
@@ -194,6 +202,213 @@ func TestUnmarshalEmpty(t *testing.T) {
 	assert.Empty(t, j)
 }
 
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		b1   []byte
+		b2   []byte
+		want []Difference
+	}{
+		{
+			name: "equal",
+			b1:   []byte(`{"a": [1, 2], "b": {"c": "d"}}`),
+			b2:   []byte(`{"b": {"c": "d"}, "a": [2, 1]}`),
+		},
+		{
+			name: "different root types",
+			b1:   []byte(`[]`),
+			b2:   []byte(`{}`),
+			want: []Difference{{Type: ValueMismatch, Path: "", Left: []interface{}{}, Right: map[string]interface{}{}}},
+		},
+		{
+			name: "nested value mismatch",
+			b1:   []byte(`{"name": {"first": "Tom", "last": "Anderson"}}`),
+			b2:   []byte(`{"name": {"first": "Tom", "last": "Murphy"}}`),
+			want: []Difference{{Type: ValueMismatch, Path: "name.last", Left: "Anderson", Right: "Murphy"}},
+		},
+		{
+			name: "missing keys",
+			b1:   []byte(`{"a": 1, "b": 2}`),
+			b2:   []byte(`{"b": 2, "c": 3}`),
+			want: []Difference{
+				{Type: MissingRight, Path: "a", Left: json.Number("1")},
+				{Type: MissingLeft, Path: "c", Right: json.Number("3")},
+			},
+		},
+		{
+			name: "unordered array element mismatch",
+			b1:   []byte(`{"friends": [{"first": "James"}, {"first": "Roger", "age": 1}]}`),
+			b2:   []byte(`{"friends": [{"first": "Roger", "age": 2}, {"first": "James"}]}`),
+			want: []Difference{{Type: ValueMismatch, Path: "friends.1.age", RightPath: "friends.0.age", Left: json.Number("1"), Right: json.Number("2")}},
+		},
+		{
+			name: "array with extra elements",
+			b1:   []byte(`[1, 2]`),
+			b2:   []byte(`[2, 3, 1, 4]`),
+			want: []Difference{
+				{Type: MissingLeft, Path: "1", Right: json.Number("3")},
+				{Type: MissingLeft, Path: "3", Right: json.Number("4")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j1, _ := Unmarshal(tt.b1)
+			j2, _ := Unmarshal(tt.b2)
+			assert.Equal(t, tt.want, Diff(j1, j2))
+		})
+	}
+}
+
+func TestDiffMatchKey(t *testing.T) {
+	c := NewComparator(MatchKey("items.#", "id"))
+
+	j1, _ := Unmarshal([]byte(`{"items": [{"id": 1, "price": 10}, {"id": 2, "price": 20}, {"id": 3, "price": 30}]}`))
+	j2, _ := Unmarshal([]byte(`{"items": [{"id": 4, "price": 40}, {"id": 2, "price": 25}, {"id": 1, "price": 10}]}`))
+
+	assert.Equal(t, []Difference{
+		{Type: ValueMismatch, Path: "items.1.price", Left: json.Number("20"), Right: json.Number("25")},
+		{Type: MissingRight, Path: "items.2", Left: map[string]interface{}{"id": json.Number("3"), "price": json.Number("30")}},
+		{Type: MissingLeft, Path: "items.0", Right: map[string]interface{}{"id": json.Number("4"), "price": json.Number("40")}},
+	}, c.Diff(j1, j2))
+}
+
+func TestParseMatchKey(t *testing.T) {
+	for _, s := range []string{"items.#=id", "items=id", "#=info.id"} {
+		_, err := ParseMatchKey(s)
+		assert.NoError(t, err, s)
+	}
+
+	for _, s := range []string{"items", "=id", "items="} {
+		_, err := ParseMatchKey(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestArrayOrder(t *testing.T) {
+	j1, _ := Unmarshal([]byte(`{"results": [1, 2, 3], "tags": ["a", "b"], "nested": [{"ids": [1, 2]}]}`))
+	j2, _ := Unmarshal([]byte(`{"results": [3, 2, 1], "tags": ["b", "a"], "nested": [{"ids": [2, 1]}]}`))
+
+	assert.True(t, NewComparator().Equal(j1, j2))
+	assert.False(t, NewComparator(StrictOrder()).Equal(j1, j2))
+
+	c := NewComparator(ArrayOrder("results", true))
+	assert.False(t, c.Equal(j1, j2))
+	assert.Equal(t, []Difference{
+		{Type: ValueMismatch, Path: "results.0", Left: json.Number("1"), Right: json.Number("3")},
+		{Type: ValueMismatch, Path: "results.2", Left: json.Number("3"), Right: json.Number("1")},
+	}, c.Diff(j1, j2))
+
+	c = NewComparator(StrictOrder(), ArrayOrder("results", false), ArrayOrder("tags.#", false))
+	assert.Equal(t, []Difference{
+		{Type: ValueMismatch, Path: "nested.0.ids.0", Left: json.Number("1"), Right: json.Number("2")},
+		{Type: ValueMismatch, Path: "nested.0.ids.1", Left: json.Number("2"), Right: json.Number("1")},
+	}, c.Diff(j1, j2))
+
+	c = NewComparator(ArrayOrder("nested.#.ids", true))
+	assert.False(t, c.Equal(j1, j2))
+	assert.Len(t, c.Diff(j1, j2), 2)
+}
+
+func TestArrayOrder_Nested(t *testing.T) {
+	j1, _ := Unmarshal([]byte(`{"matrix": [[1, 2], [3, 4]]}`))
+	j2, _ := Unmarshal([]byte(`{"matrix": [[2, 1], [4, 3]]}`))
+
+	c := NewComparator(ArrayOrder("matrix", true))
+	assert.True(t, c.Equal(j1, j2))
+	assert.Empty(t, c.Diff(j1, j2))
+
+	c = NewComparator(ArrayOrder("matrix.#.#", true))
+	assert.False(t, c.Equal(j1, j2))
+	assert.Len(t, c.Diff(j1, j2), 4)
+
+	j1, _ = Unmarshal([]byte(`[[1, 2], [3, 4]]`))
+	j2, _ = Unmarshal([]byte(`[[2, 1], [4, 3]]`))
+
+	c = NewComparator(ArrayOrder("#", true))
+	assert.True(t, c.Equal(j1, j2))
+	assert.Empty(t, c.Diff(j1, j2))
+
+	c = NewComparator(ArrayOrder("#.#", true))
+	assert.False(t, c.Equal(j1, j2))
+	assert.Len(t, c.Diff(j1, j2), 4)
+}
+
+func TestParseArrayOrder(t *testing.T) {
+	for _, s := range []string{"results=ordered", "tags.#=unordered", "#=ordered"} {
+		_, err := ParseArrayOrder(s)
+		assert.NoError(t, err, s)
+	}
+
+	for _, s := range []string{"results", "results=sorted", "=ordered"} {
+		_, err := ParseArrayOrder(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	j1, _ := Unmarshal([]byte(`{"id": 9007199254740993, "price": 10.0, "items": [{"price": 1.004}, {"price": 2}]}`))
+	j2, _ := Unmarshal([]byte(`{"id": 9007199254740992, "price": 1e1, "items": [{"price": 2.001}, {"price": 1}]}`))
+
+	assert.Equal(t, []Difference{
+		{Type: ValueMismatch, Path: "id", Left: json.Number("9007199254740993"), Right: json.Number("9007199254740992")},
+		{Type: ValueMismatch, Path: "items.0.price", Left: json.Number("1.004"), Right: json.Number("2.001")},
+		{Type: ValueMismatch, Path: "items.1.price", Left: json.Number("2"), Right: json.Number("1")},
+	}, Diff(j1, j2))
+
+	c := NewComparator(Tolerance("items.#.price", 0.01, false), Tolerance("id", 1, false))
+	assert.True(t, c.Equal(j1, j2))
+	assert.Empty(t, c.Diff(j1, j2))
+
+	c = NewComparator(Tolerance("", 0.001, true))
+	assert.False(t, c.Equal(j1, j2))
+	assert.Equal(t, []Difference{
+		{Type: ValueMismatch, Path: "items.0.price", RightPath: "items.1.price", Left: json.Number("1.004"), Right: json.Number("1")},
+	}, c.Diff(j1, j2))
+}
+
+func TestParseTolerance(t *testing.T) {
+	for _, s := range []string{"0.01", "1%", "price=0.01", "items.#.price=1%"} {
+		_, err := ParseTolerance(s)
+		assert.NoError(t, err, s)
+	}
+
+	for _, s := range []string{"", "price", "=0.01", "price=-1", "price=a%"} {
+		_, err := ParseTolerance(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestEqualLargeArray(t *testing.T) {
+	x, y := makeLargeArrays(5000)
+	assert.True(t, Equal(x, y))
+	assert.Empty(t, Diff(x, y))
+
+	y[10].(map[string]interface{})["price"] = float64(-1)
+	assert.False(t, Equal(x, y))
+	assert.Len(t, NewComparator(MatchKey("#", "id")).Diff(x, y), 1)
+}
+
+// makeLargeArrays returns two arrays with the same elements in reverse order.
+func makeLargeArrays(n int) (x, y []interface{}) {
+	x = make([]interface{}, n)
+	y = make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		x[i] = map[string]interface{}{"id": float64(i), "price": float64(i * 10), "tags": []interface{}{"a", "b"}}
+		y[n-1-i] = map[string]interface{}{"id": float64(i), "price": float64(i * 10), "tags": []interface{}{"b", "a"}}
+	}
+
+	return x, y
+}
+
+func BenchmarkEqualLargeArray(b *testing.B) {
+	x, y := makeLargeArrays(5000)
+	for i := 0; i < b.N; i++ {
+		Equal(x, y)
+	}
+}
+
 func BenchmarkEqual(b *testing.B) {
 	tests := []struct {
 		name    string
@@ -237,6 +452,26 @@ func BenchmarkEqual(b *testing.B) {
 	}
 }
 
+func TestReplace(t *testing.T) {
+	j, _ := Unmarshal([]byte(`{"a":{"b":[{"c":1},{"c":2},{"d":3}]},"e":4}`))
+
+	var paths []string
+	Replace(j, "a.b.#.c", func(path string, v interface{}) interface{} {
+		paths = append(paths, path)
+
+		return "x"
+	})
+	Replace(j, "missing.key", func(path string, v interface{}) interface{} {
+		paths = append(paths, path)
+
+		return v
+	})
+
+	want, _ := Unmarshal([]byte(`{"a":{"b":[{"c":"x"},{"c":"x"},{"d":3}]},"e":4}`))
+	assert.Equal(t, want, j)
+	assert.Equal(t, []string{"a.b.0.c", "a.b.1.c"}, paths)
+}
+
 func TestRemove(t *testing.T) {
 	tests := []struct {
 		name string
@@ -321,6 +556,13 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestNormalizePath(t *testing.T) {
+	assert.Equal(t, "", normalizePath(""))
+	assert.Equal(t, "results.#.id", normalizePath("results.3.id"))
+	assert.Equal(t, "#.#", normalizePath("0.12"))
+	assert.Equal(t, "name.first", normalizePath("name.first"))
+}
+
 func BenchmarkRemove(b *testing.B) {
 	input, _ := Unmarshal([]byte(`{"paging":{"total":101,"limit":30,"offset":0},"results":[{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/visa.gif","payer_costs":[{"installment_reduced_tea":69,"installment_reduced_cft":87.81,"installment_rate":0,"installment_full_cft":87.81,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":69,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696449,"max_allowed_amount":700000,"base_installment_rate":0,"payment_method_option_id":"1.AQokYWQxNjI1ZjMtYjVjZi00NjE3LWI2YTgtZTkwNjE2OTU0MzJkEJrp_cLiLQ"},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696450,"max_allowed_amount":700000,"base_installment_rate":18.22,"payment_method_option_id":"1.AQokMzRiYzNjMmMtMzhhZC00ZDk4LWE4MTYtYzRiY2Y3MzE5MWViEJvp_cLiLQ"},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696451,"max_allowed_amount":700000,"base_installment_rate":33.21,"payment_method_option_id":"1.AQokMmZlNGZjMjgtNTM2Ni00NWE4LWI5ZTAtODljNmYxZGE4Y2ZlEJvp_cLiLQ"},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696452,"max_allowed_amount":700000,"base_installment_rate":49.19,"payment_method_option_id":"1.AQokODQ0ZmMzOGMtYTY0Yi00ODA3LWJlYzctMmFlMzU1NGFjNWM1EJvp_cLiLQ"},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696453,"max_allowed_amount":700000,"base_installment_rate":63.77,"payment_method_option_id":"1.AQokOTEzOWRiYTYtMTg0OC00ZjdhLWE3ZWItOGQyMGZlOTBmOWY3EJvp_cLiLQ"}],"issuer":{"default":true,"name":"Visa Argentina S.A.","id":1},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(419080|476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67696455}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/visa.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696457","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_name","cardholder_identification_type","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/1078.gif","payer_costs":[{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":474425245,"max_allowed_amount":700000,"base_installment_rate":0,"payment_method_option_id":"1.AQokYzU2MTFjODEtODY2Ni00Zjc0LThmOTktMjNiNzg1NWUxZTgzEJvp_cLiLQ"},{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["recommended_installment","CFT_0,00%|TEA_0,00%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":474425246,"max_allowed_amount":700000,"base_installment_rate":0,"payment_method_option_id":"1.AQokZDE5MzIxM2EtYzdlYi00MWYzLTg4NzUtMjE0YTA4NzhlMmZiEJvp_cLiLQ"},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":107909945,"max_allowed_amount":700000,"base_installment_rate":33.21,"payment_method_option_id":"1.AQokM2NkYmY3N2ItNjk5Zi00OTAwLTg4Y2QtZjkzOGZmOTk4NGNlEJvp_cLiLQ"},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":107909946,"max_allowed_amount":700000,"base_installment_rate":49.19,"payment_method_option_id":"1.AQokNTFlMTA4YWQtMmIyZS00ODk0LWI5MDUtOTM4NGM5ZWI3ZmE3EJvp_cLiLQ"},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":107909947,"max_allowed_amount":700000,"base_installment_rate":63.77,"payment_method_option_id":"1.AQokN2MwMjAyOGQtNGI3ZS00MTY4LThjMzItMDg3YWQ2MDc2NzY1EJvp_cLiLQ"}],"issuer":{"default":false,"name":"Mercado Pago + Banco Patagonia","id":1078},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"master","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(5|(2(221|222|223|224|225|226|227|228|229|23|24|25|26|27|28|29|3|4|5|6|70|71|720)))","installments_pattern":"^(5|(2(221|222|223|224|225|226|227|228|229|23|24|25|26|27|28|29|3|4|5|6|70|71|720)))","exclusion_pattern":"^(514256|514586|526461|511309|514285|501059|557909|501082|589633|501060|501051|501016|589657|553839|525855|553777|553771|551792|528733|549180|528745|517562|511849|557648|546367|501070|601782|508143|501085|501074|501073|501071|501068|501066|589671|589633|588729|501089|501083|501082|501081|501080|501075|501067|501062|501061|501060|501058|501057|501056|501055|501054|501053|501051|501049|501047|501045|501043|501041|501040|501039|501038|501029|501028|501027|501026|501025|501024|501023|501021|501020|501018|501016|501015|589657|589562|501105|557039|542702|544764|550073|528824|522135|522137|562397|566694|566783|568382|569322|504363|504338|504777)"},"id":107909952}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/1078.gif","bins":[515073,515070,532383,532384],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"107909955","financing_deals":{"legals":null,"installments":[1,3],"expiration_date":"2020-01-01T02:59:59.000Z","start_date":"2019-02-01T03:00:00.000Z","status":"active"},"name":"Mastercard","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number","issuer_id"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/master.gif","payer_costs":[{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696521,"max_allowed_amount":700000,"base_installment_rate":0,"payment_method_option_id":"1.AQokNGM5YmUzNTMtMmI2Mi00YjBmLTk1YmEtNTdjNzYwM2YzYmNkEJvp_cLiLQ"},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696522,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696523,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696524,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696525,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Mastercard","id":3},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"master","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(5|(2(221|222|223|224|225|226|227|228|229|23|24|25|26|27|28|29|3|4|5|6|70|71|720)))","installments_pattern":"^(?!554730)","exclusion_pattern":"^(593628|592501|593626|514256|514586|526461|511309|514285|501059|557909|501082|589633|501060|501051|501016|589657|553839|525855|553777|553771|551792|528733|549180|528745|517562|511849|557648|546367|501070|601782|508143|501085|501074|501073|501071|501068|501066|589671|589633|588729|501089|501083|501082|501081|501080|501075|501067|501062|501061|501060|501058|501057|501056|501055|501054|501053|501051|501049|501047|501045|501043|501041|501040|501039|501038|501029|501028|501027|501026|501025|501024|501023|501021|501020|501018|501016|501015|589657|589562|501105|557039|542702|544764|550073|528824|522135|522137|562397|566694|566783|568382|569322|504363|504338|504777)"},"id":67696527}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/master.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696529","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Mastercard","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number","issuer_id"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/288.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70581538,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70581539,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70581540,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70581541,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70581542,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Tarjeta Shopping","id":288},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(483154)","installments_pattern":"^(483154)","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":70581545}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/288.gif","bins":[483154],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"70581547","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/amex.gif","payer_costs":[{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696458,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":237.06,"installment_reduced_cft":325,"installment_rate":26.66,"installment_full_cft":325,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":237.06,"labels":["CFT_325,00%|TEA_237,06%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696459,"max_allowed_amount":700000,"base_installment_rate":26.66},{"installment_reduced_tea":240.08,"installment_reduced_cft":325,"installment_rate":49.32,"installment_full_cft":325,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":240.08,"labels":["CFT_325,00%|TEA_240,08%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696460,"max_allowed_amount":700000,"base_installment_rate":49.32},{"installment_reduced_tea":259.93,"installment_reduced_cft":350,"installment_rate":77.69,"installment_full_cft":350,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":259.93,"labels":["CFT_350,00%|TEA_259,93%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696461,"max_allowed_amount":700000,"base_installment_rate":77.69},{"installment_reduced_tea":261.98,"installment_reduced_cft":350,"installment_rate":106.02,"installment_full_cft":350,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":261.98,"labels":["recommended_installment","CFT_350,00%|TEA_261,98%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696462,"max_allowed_amount":700000,"base_installment_rate":106.02}],"issuer":{"default":true,"name":"American Express","id":2},"total_financial_cost":3.67,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"amex","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"front","length":4},"card_number":{"length":15,"validation":"standard"},"bin":{"pattern":"^((34)|(37))","installments_pattern":"^((34)|(37))","exclusion_pattern":null},"id":67696464}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/amex.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696466","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"American Express","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_identification_type","cardholder_name"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/692.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700406,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700407,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700408,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700409,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700410,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Cencosud","id":692},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"master","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(5|(2(221|222|223|224|225|226|227|228|229|23|24|25|26|27|28|29|3|4|5|6|70|71|720)))","installments_pattern":"^(5|(2(221|222|223|224|225|226|227|228|229|23|24|25|26|27|28|29|3|4|5|6|70|71|720)))","exclusion_pattern":"^(514256|514586|526461|511309|514285|501059|557909|501082|589633|501060|501051|501016|589657|553839|525855|553777|553771|551792|528733|549180|528745|517562|511849|557648|546367|501070|601782|508143|501085|501074|501073|501071|501068|501066|589671|589633|588729|501089|501083|501082|501081|501080|501075|501067|501062|501061|501060|501058|501057|501056|501055|501054|501053|501051|501049|501047|501045|501043|501041|501040|501039|501038|501029|501028|501027|501026|501025|501024|501023|501021|501020|501018|501016|501015|589657|589562|501105|557039|542702|544764|550073|528824|522135|522137|562397|566694|566783|568382|569322|504363|504338|504777)"},"id":67700414}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/692.gif","bins":[527104,559198,510541],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67700416","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Mastercard","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_identification_type","cardholder_name","issuer_id"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/1007.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":71198203,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":324942337,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":190633767,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":71198207,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Nativa Mastercard","id":1007},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"master","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(5|(2(221|222|223|224|225|226|227|228|229|23|24|25|26|27|28|29|3|4|5|6|70|71|720)))","installments_pattern":"^(5|(2(221|222|223|224|225|226|227|228|229|23|24|25|26|27|28|29|3|4|5|6|70|71|720)))","exclusion_pattern":"^(514256|514586|526461|511309|514285|501059|557909|501082|589633|501060|501051|501016|589657|553839|525855|553777|553771|551792|528733|549180|528745|517562|511849|557648|546367|501070|601782|508143|501085|501074|501073|501071|501068|501066|589671|589633|588729|501089|501083|501082|501081|501080|501075|501067|501062|501061|501060|501058|501057|501056|501055|501054|501053|501051|501049|501047|501045|501043|501041|501040|501039|501038|501029|501028|501027|501026|501025|501024|501023|501021|501020|501018|501016|501015|589657|589562|501105|557039|542702|544764|550073|528824|522135|522137|562397|566694|566783|568382|569322|504363|504338|504777)"},"id":71198211}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/1007.gif","bins":[546553,520053,527601],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"71198213","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Mastercard","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_identification_type","cardholder_name","issuer_id"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/mercadopago_cc.gif","payer_costs":[{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":474420757,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["recommended_installment","CFT_0,00%|TEA_0,00%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":474420758,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":105561043,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":105561044,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":105561045,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Mercado Pago + Banco Patagonia","id":1078},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"mercadopago_cc","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^((515073)|(515070)|(532384))","installments_pattern":"^((515073)|(515070)|(532384))","exclusion_pattern":null},"id":105561050},{"security_code":{"mode":"optional","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(532383)","installments_pattern":"^(532383)","exclusion_pattern":null},"id":173116724}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/mercadopago_cc.gif","bins":[515073,515070,532383,532384],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"105561052","financing_deals":{"legals":null,"installments":[1,3],"expiration_date":"2020-01-01T02:59:59.000Z","start_date":"2019-02-01T03:00:00.000Z","status":"active"},"name":"Mercado Pago + Banco Patagonia","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_identification_type","cardholder_name"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/naranja.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":0,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696596,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":237.06,"installment_reduced_cft":325,"installment_rate":26.66,"installment_full_cft":325,"discount_rate":0,"min_allowed_amount":2,"installment_full_tea":237.06,"labels":["CFT_325,00%|TEA_237,06%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696597,"max_allowed_amount":700000,"base_installment_rate":26.66}],"issuer":{"default":true,"name":"Naranja","id":5},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"naranja","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"none"},"bin":{"pattern":"^(589562)","installments_pattern":"^(589562)","exclusion_pattern":null},"id":67696601}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/naranja.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696604","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Naranja","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/nativa.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696614,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696615,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":190633803,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696618,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Nativa Mastercard","id":1007},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"nativa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^((520053)|(546553)|(554472)|(531847)|(527601))","installments_pattern":"^((520053)|(546553)|(554472)|(531847)|(527601))","exclusion_pattern":null},"id":67696620}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/nativa.gif","bins":[554472,531847,527601,546553,520053],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696622","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Nativa Mastercard","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/tarshop.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":0,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696648,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":2,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696649,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":3,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696650,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":5,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696651,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":6,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696652,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Tarjeta Shopping","id":288},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"tarshop","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"optional","card_location":"back","length":0},"card_number":{"length":13,"validation":"none"},"bin":{"pattern":"^(27995)","installments_pattern":"^(27995)","exclusion_pattern":null},"id":67696655}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/tarshop.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":[],"payment_method_id":"67696657","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Tarjeta Shopping","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_name","cardholder_identification_number","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/cabal.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":0,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700095,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":2,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700096,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":5,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":224040094,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":6,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":224040119,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":6,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":224040132,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Cabal","id":688},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"cabal","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^((627170)|(589657)|(603522)|(604((20[1-9])|(2[1-9][0-9])|(3[0-9]{2})|(400))))","installments_pattern":"^((627170)|(589657)|(603522)|(604((20[1-9])|(2[1-9][0-9])|(3[0-9]{2})|(400))))","exclusion_pattern":"^((604201)|(604209))"},"id":67700101}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/cabal.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":[],"payment_method_id":"67700103","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Cabal","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_name","cardholder_identification_type","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/cencosud.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":0,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700113,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":2,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700114,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":3,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700115,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":5,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700116,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":6,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700117,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Cencosud","id":692},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"cencosud","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(603493)","installments_pattern":"^(603493)","exclusion_pattern":null},"id":67700119}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/cencosud.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":[],"payment_method_id":"67700121","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Cencosud","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_name","cardholder_identification_type","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/diners.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700131,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700132,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700133,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700134,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700135,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Diners","id":1028},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"diners","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":14,"validation":"standard"},"bin":{"pattern":"^((30)|(36)|(38))","installments_pattern":"^((360935)|(360936))","exclusion_pattern":"^((3646)|(3648))"},"id":67700137}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/diners.gif","bins":[],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":[],"payment_method_id":"67700139","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Diners","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/11.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":10,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696632,"max_allowed_amount":60000,"base_installment_rate":0}],"issuer":{"default":false,"name":"PagoFacil","id":11},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":1,"merchant_account_id":null,"id":"pagofacil","payment_type_id":"ticket","accreditation_time":0,"owner":"site","settings":[],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/11.gif","bins":[],"marketplace":"NONE","deferred_capture":"does_not_apply","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696635","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Pago Fácil","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":[],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/argencard.gif","payer_costs":[{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700073,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700074,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700075,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700076,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700077,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":true,"name":"Argencard S.A.","id":4},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"argencard","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(501105)","installments_pattern":"^(501105)","exclusion_pattern":"^((589562)|(527571)|(527572))"},"id":67700079}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/argencard.gif","bins":[501105],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":[],"payment_method_id":"67700081","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Argencard","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/maestro.gif","payer_costs":[{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":148784059,"max_allowed_amount":700000,"base_installment_rate":0}],"issuer":{"default":true,"name":"Mastercard","id":3},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":1,"merchant_account_id":null,"id":"maestro","payment_type_id":"debit_card","accreditation_time":1440,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":18,"validation":"none"},"bin":{"pattern":"^(501051|501059|557909|501066|588729|501075|501062|501060|501057|501056|501055|501053|501043|501041|501038|501028|501023|501021|501020|501018|501016)","installments_pattern":null,"exclusion_pattern":null},"id":148784074},{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":19,"validation":"none"},"bin":{"pattern":"^(601782|508143|501081|501080)","installments_pattern":null,"exclusion_pattern":null},"id":148784075}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/maestro.gif","bins":[501020,501021,501023,501062,501038,501057,588729,501041,501080,501081,501056,501075,501043,501016,501060,501051,501028,501055,601782,501018,589671,508143,501066,501053,501059,557909],"marketplace":"NONE","deferred_capture":"unsupported","agreements":[],"labels":[],"payment_method_id":"148784077","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Maestro","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_identification_type","cardholder_name"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/debmaster.gif","payer_costs":[{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":148784010,"max_allowed_amount":700000,"base_installment_rate":0}],"issuer":{"default":true,"name":"Mastercard","id":3},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":1,"merchant_account_id":null,"id":"debmaster","payment_type_id":"debit_card","accreditation_time":1440,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(526461|514365|514256|514586|525855|511309|514285|553839|553777|553771|551792|528733|549180|528745|517562|511849|557648|546367)","installments_pattern":"^(526461|514365|514256|514586|525855|511309|514285|553839|553777|553771|551792|528733|549180|528745|517562|511849|557648|546367)","exclusion_pattern":null},"id":148784023}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/debmaster.gif","bins":[546367,557648,511849,517562,528745,549180,528733,551792,553771,553777,553839,525855,511309,514285,514586,526461,514256,514365,230937,230933],"marketplace":"NONE","deferred_capture":"unsupported","agreements":[],"labels":[],"payment_method_id":"148784025","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Mastercard Débito","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_identification_type","cardholder_name","issuer_id"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/debcabal.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":0,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":148784001,"max_allowed_amount":700000,"base_installment_rate":0}],"issuer":{"default":true,"name":"Cabal","id":688},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":1,"merchant_account_id":null,"id":"debcabal","payment_type_id":"debit_card","accreditation_time":1440,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^(604201)","installments_pattern":"^(604201)","exclusion_pattern":null},"id":148784004}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/debcabal.gif","bins":[604201],"marketplace":"NONE","deferred_capture":"unsupported","agreements":[],"labels":[],"payment_method_id":"148784006","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Cabal Débito","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_identification_type","cardholder_name"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/1005.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696692,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696693,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":428726426,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696695,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696696,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Provencred","id":1005},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67696699}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/1005.gif","bins":[410718,482470],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696701","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/272.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696712,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696713,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696714,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696715,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696716,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Banco Comafi","id":272},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67696726}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/272.gif","bins":[402785,402786,402788,433807,462084],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696728","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/294.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696743,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696744,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696745,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696746,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67696747,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Banco Hipotecario","id":294},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67696754}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/294.gif","bins":[430495,430496,430497,400103,400104,442015,442014],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67696756","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/1026.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700457,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700458,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700459,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700460,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700461,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Banco Industrial","id":1026},"total_financial_cost":5.4,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67700470}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/1026.gif","bins":[456564,456565,456566,489467,485482,462937,486657],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67700472","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/282.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700506,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700507,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":226835584,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700510,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Banco Nacion","id":282},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67700517}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/282.gif","bins":[479375,479376,433826,479378,423985],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67700519","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/303.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700520,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":0,"installment_reduced_cft":0,"installment_rate":0,"installment_full_cft":0,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":0,"labels":["recommended_installment","CFT_0,00%|TEA_0,00%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":[],"id":544549412,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700522,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700523,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700524,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Banco Patagonia","id":303},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67700534}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/303.gif","bins":[475393,450833,450832,450994,454644,454643,454645,469701],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67700536","financing_deals":{"legals":null,"installments":[3],"expiration_date":"2019-11-02T02:59:59.000Z","start_date":"2019-11-01T03:00:00.000Z","status":"active"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/326.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700537,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700538,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700539,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700540,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700541,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"HSBC","id":326},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67700556}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/326.gif","bins":[450911,450910,455349,459474,469724,433851,425821,490699,492043,492137,469725,425822,465403,465494],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67700558","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/338.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700581,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700582,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700583,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700584,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":67700585,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"ICBC","id":338},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":67700593}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/338.gif","bins":[454658,454657,454659,482469,442312],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"67700595","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_number","cardholder_name","cardholder_identification_type"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/297.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70570748,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70570749,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70570750,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70570751,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":70570752,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Macro","id":297},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":70570766}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/297.gif","bins":[450844,450843,455599,469771,469700,457073,457074,457075,448730,448729,411011,411010],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"70570768","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/279.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912636,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912637,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912638,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912639,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912640,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Banco Galicia","id":279},"total_financial_cost":null,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":74912653}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/279.gif","bins":[454641,454642,404205,433830,493702,432030,411436,448493,486604,459354,454640,492598,492597,492596,476590],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"74912655","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number"],"status":"active"},{"financial_institutions":[],"secure_thumbnail":"https://www.mercadopago.com/org-img/MP3/API/logos/287.gif","payer_costs":[{"installment_reduced_tea":null,"installment_reduced_cft":null,"installment_rate":0,"installment_full_cft":null,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":null,"labels":["CFT_0,00%|TEA_0,00%"],"installments":1,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912656,"max_allowed_amount":700000,"base_installment_rate":0},{"installment_reduced_tea":134.39,"installment_reduced_cft":177,"installment_rate":18.22,"installment_full_cft":177,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":134.39,"labels":["CFT_177,00%|TEA_134,39%"],"installments":3,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912657,"max_allowed_amount":700000,"base_installment_rate":18.22},{"installment_reduced_tea":135.6,"installment_reduced_cft":177.08,"installment_rate":33.21,"installment_full_cft":177.08,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":135.6,"labels":["CFT_177,08%|TEA_135,60%","recommended_interest_installment_with_some_banks"],"installments":6,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912658,"max_allowed_amount":700000,"base_installment_rate":33.21},{"installment_reduced_tea":136.21,"installment_reduced_cft":176.57,"installment_rate":49.19,"installment_full_cft":176.57,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":136.21,"labels":["CFT_176,57%|TEA_136,21%"],"installments":9,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912659,"max_allowed_amount":700000,"base_installment_rate":49.19},{"installment_reduced_tea":130.33,"installment_reduced_cft":167.52,"installment_rate":63.77,"installment_full_cft":167.52,"discount_rate":0,"min_allowed_amount":1,"installment_full_tea":130.33,"labels":["recommended_installment","CFT_167,52%|TEA_130,33%"],"installments":12,"reimbursement_rate":null,"installment_rate_collector":["MERCADOPAGO"],"id":74912660,"max_allowed_amount":700000,"base_installment_rate":63.77}],"issuer":{"default":false,"name":"Banco Santa Cruz","id":287},"total_financial_cost":0,"min_accreditation_days":0,"max_accreditation_days":2,"merchant_account_id":null,"id":"visa","payment_type_id":"credit_card","accreditation_time":2880,"owner":"site","settings":[{"security_code":{"mode":"mandatory","card_location":"back","length":3},"card_number":{"length":16,"validation":"standard"},"bin":{"pattern":"^4","installments_pattern":"^4","exclusion_pattern":"^(476520|473713|473713|473227|444493|410122|405517|402789|417856|448712|453770|434541|411199|423465|434540|434542|434538|423018|488241|489634|434537|434539|434536|427156|427157|434535|434534|434533|423077|434532|434586|423001|434531|411197|443264|400276|400615|402914|404625|405069|434543|416679|405515|405516|405755|405896|405897|406290|406291|406375|406652|406998|406999|408515|410082|410083|410121|410123|410853|411849|417309|421738|423623|428062|428063|428064|434795|437996|439818|442371|442548|444060|446343|446344|446347|450412|450799|451377|451701|451751|451756|451757|451758|451761|451763|451764|451765|451766|451767|451768|451769|451770|451772|451773|457596|457665|462815|463465|468508|473710|473711|473712|473714|473715|473716|473717|473718|473719|473720|473721|473722|473725|477051|477053|481397|481501|481502|481550|483002|483020|483188|489412|492528|499859|446344|446345|446346|400448)"},"id":74912665}],"thumbnail":"http://img.mlstatic.com/org-img/MP3/API/logos/287.gif","bins":[456513,446866,424989,421739],"marketplace":"NONE","deferred_capture":"supported","agreements":[],"labels":["recommended_method"],"payment_method_id":"74912667","financing_deals":{"legals":null,"installments":null,"expiration_date":null,"start_date":null,"status":"deactive"},"name":"Visa","site_id":"MLA","processing_mode":"aggregator","additional_info_needed":["cardholder_identification_type","cardholder_name","cardholder_identification_number"],"status":"active"}]}`))
 	key := "results.#.payer_costs.#.payment_method_option_id"
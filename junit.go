@@ -0,0 +1,427 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Supported ways of grouping results in test cases of a JUnit report.
+const (
+	// JUnitGroupURL reports every url as a test case.
+	JUnitGroupURL = "url"
+	// JUnitGroupEndpoint reports every endpoint group as a test case, failing if any of its urls does.
+	JUnitGroupEndpoint = "endpoint"
+)
+
+// maxJUnitDetails is the maximum number of urls detailed in the failure of an endpoint test case.
+const maxJUnitDetails = 100
+
+// JUnitReport is a Recorder that collects every Result to write them as a JUnit xml report, so a run can be rendered
+// by CI systems like any other test stage. Mismatches are reported as failures and errors as errors.
+type JUnitReport struct {
+	mu    sync.Mutex
+	hosts []string
+	group string
+	// cases holds the test case of every url, which is written as soon as it is recorded so it is not kept in memory.
+	cases    *os.File
+	casesEnc *xml.Encoder
+	counts   junitCounts
+	groups   map[string]*junitGroup
+	err      error
+}
+
+// junitCounts tallies the test cases of a suite.
+type junitCounts struct {
+	tests, failures, errors int
+	elapsed                 time.Duration
+}
+
+func (c *junitCounts) add(tc junitTestCase) {
+	c.tests++
+	c.elapsed += tc.elapsed
+	if tc.Failure != nil {
+		c.failures++
+	}
+
+	if tc.Error != nil {
+		c.errors++
+	}
+}
+
+func (c junitCounts) attrs() []xml.Attr {
+	return []xml.Attr{
+		{Name: xml.Name{Local: "tests"}, Value: strconv.Itoa(c.tests)},
+		{Name: xml.Name{Local: "failures"}, Value: strconv.Itoa(c.failures)},
+		{Name: xml.Name{Local: "errors"}, Value: strconv.Itoa(c.errors)},
+		{Name: xml.Name{Local: "time"}, Value: formatSeconds(c.elapsed)},
+	}
+}
+
+// junitGroup holds the results of an endpoint group that did not pass, up to maxJUnitDetails of each kind.
+type junitGroup struct {
+	total                int
+	elapsed              time.Duration
+	mismatches, failures int
+	mismatched, failed   []Result
+}
+
+type junitTestCase struct {
+	XMLName   xml.Name      `xml:"testcase"`
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	elapsed   time.Duration
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// NewJUnitReport returns a JUnitReport with a test case for every url or endpoint group, as given by group.
+// The test cases of every url are kept in a temporary file, which is removed by Close.
+func NewJUnitReport(hosts []string, group string) (*JUnitReport, error) {
+	if err := validateJUnitGroup(group); err != nil {
+		return nil, err
+	}
+
+	j := &JUnitReport{
+		hosts:  hosts,
+		group:  group,
+		groups: make(map[string]*junitGroup),
+	}
+
+	if group == JUnitGroupURL {
+		f, err := ioutil.TempFile("", "gomparator-junit")
+		if err != nil {
+			return nil, err
+		}
+
+		j.cases = f
+		j.casesEnc = newJUnitCaseEncoder(f)
+	}
+
+	return j, nil
+}
+
+// validateJUnitGroup returns an error if group is not one of the supported ways of grouping results.
+func validateJUnitGroup(group string) error {
+	if group != JUnitGroupURL && group != JUnitGroupEndpoint {
+		return fmt.Errorf("invalid junit group %q: expected %s or %s", group, JUnitGroupURL, JUnitGroupEndpoint)
+	}
+
+	return nil
+}
+
+func (j *JUnitReport) Record(r Result) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.group == JUnitGroupEndpoint {
+		j.recordGroup(r)
+
+		return
+	}
+
+	tc := junitTestCase{
+		Name:      requestName(r),
+		ClassName: endpointGroup(r.RelURL),
+		elapsed:   elapsed(r),
+	}
+	tc.Time = formatSeconds(tc.elapsed)
+
+	switch r.Verdict {
+	case VerdictEqual:
+	case VerdictError:
+		tc.Error = &junitFailure{Message: string(r.Verdict), Type: string(r.Verdict), Text: j.describe(r)}
+	default:
+		tc.Failure = &junitFailure{Message: string(r.Verdict), Type: string(r.Verdict), Text: j.describe(r)}
+	}
+
+	if j.err != nil {
+		return
+	}
+
+	if err := j.casesEnc.Encode(tc); err != nil {
+		j.err = fmt.Errorf("could not write junit test case: %v", err)
+
+		return
+	}
+	j.counts.add(tc)
+}
+
+func (j *JUnitReport) recordGroup(r Result) {
+	name := endpointGroup(r.RelURL)
+	g, ok := j.groups[name]
+	if !ok {
+		g = &junitGroup{}
+		j.groups[name] = g
+	}
+
+	g.total++
+	g.elapsed += elapsed(r)
+
+	// Bodies are not needed to describe a failure, so they are not retained.
+	r.Hosts = withoutBodies(r.Hosts)
+	switch r.Verdict {
+	case VerdictEqual:
+	case VerdictError:
+		g.failures++
+		if len(g.failed) < maxJUnitDetails {
+			g.failed = append(g.failed, r)
+		}
+	default:
+		g.mismatches++
+		if len(g.mismatched) < maxJUnitDetails {
+			g.mismatched = append(g.mismatched, r)
+		}
+	}
+}
+
+// Write renders the report as a JUnit xml document with a single test suite.
+func (j *JUnitReport) Write(w io.Writer) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.err != nil {
+		return j.err
+	}
+
+	counts := j.counts
+	var cases []junitTestCase
+	if j.group == JUnitGroupEndpoint {
+		counts = junitCounts{}
+		cases = j.endpointCases()
+		for i, tc := range cases {
+			cases[i].Time = formatSeconds(tc.elapsed)
+			counts.add(tc)
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	// The test cases are not known by the encoder of the suite, so the closing tags are written along with them.
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	suites := xml.StartElement{Name: xml.Name{Local: "testsuites"}, Attr: counts.attrs()}
+	suite := xml.StartElement{
+		Name: xml.Name{Local: "testsuite"},
+		Attr: append([]xml.Attr{{Name: xml.Name{Local: "name"}, Value: "gomparator " + strings.Join(j.hosts, " ")}}, counts.attrs()...),
+	}
+
+	for _, t := range []xml.Token{suites, suite} {
+		if err := enc.EncodeToken(t); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+
+	if counts.tests > 0 {
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+
+	if err := j.writeCases(w, cases); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n  </testsuite>\n</testsuites>\n")
+
+	return err
+}
+
+// writeCases writes the given test cases or, if there are none, the ones of every url written so far.
+func (j *JUnitReport) writeCases(w io.Writer, cases []junitTestCase) error {
+	if j.cases == nil {
+		enc := newJUnitCaseEncoder(w)
+		for _, tc := range cases {
+			if err := enc.Encode(tc); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// The cases are read from the beginning of the file, which is still being appended to.
+	_, err := io.Copy(w, io.NewSectionReader(j.cases, 0, math.MaxInt64))
+
+	return err
+}
+
+// WriteFile renders the report as a JUnit xml document in the given file.
+func (j *JUnitReport) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := j.Write(f); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+// Close removes the temporary file holding the test cases, if any.
+func (j *JUnitReport) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.cases == nil {
+		return nil
+	}
+
+	j.cases.Close()
+	err := os.Remove(j.cases.Name())
+	j.cases = nil
+
+	return err
+}
+
+// newJUnitCaseEncoder returns an encoder of the test cases of a suite, indented as the children of the suite.
+func newJUnitCaseEncoder(w io.Writer) *xml.Encoder {
+	enc := xml.NewEncoder(w)
+	enc.Indent("    ", "  ")
+
+	return enc
+}
+
+// endpointCases returns a test case for every endpoint group, which fails if any of its urls mismatched
+// and errors if any of them could not be compared otherwise.
+func (j *JUnitReport) endpointCases() []junitTestCase {
+	names := make([]string, 0, len(j.groups))
+	for name := range j.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cases := make([]junitTestCase, 0, len(names))
+	for _, name := range names {
+		g := j.groups[name]
+		tc := junitTestCase{Name: name, ClassName: "gomparator", elapsed: g.elapsed}
+
+		switch {
+		case g.mismatches > 0:
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d of %d urls mismatched, %d failed", g.mismatches, g.total, g.failures),
+				Type:    "mismatch",
+				Text:    j.describeAll(g.mismatched, g.mismatches) + j.describeAll(g.failed, g.failures),
+			}
+		case g.failures > 0:
+			tc.Error = &junitFailure{
+				Message: fmt.Sprintf("%d of %d urls failed", g.failures, g.total),
+				Type:    string(VerdictError),
+				Text:    j.describeAll(g.failed, g.failures),
+			}
+		}
+
+		cases = append(cases, tc)
+	}
+
+	return cases
+}
+
+// describeAll describes the results one after the other, noting how many of the total count were left out.
+func (j *JUnitReport) describeAll(results []Result, count int) string {
+	var b strings.Builder
+	for _, r := range results {
+		fmt.Fprintf(&b, "%s: %s\n", requestName(r), r.Verdict)
+		b.WriteString(j.describe(r))
+	}
+
+	if count > len(results) {
+		fmt.Fprintf(&b, "%d more urls not shown\n", count-len(results))
+	}
+
+	return b.String()
+}
+
+// describe returns the errors of a result or the differences found in every comparison that is not equal.
+func (j *JUnitReport) describe(r Result) string {
+	var b strings.Builder
+	for _, err := range r.Errors {
+		fmt.Fprintf(&b, "  %v\n", err)
+	}
+
+	for _, cmp := range r.Comparisons {
+		if cmp.Verdict == VerdictEqual || cmp.Left >= len(r.Hosts) || cmp.Right >= len(r.Hosts) {
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s - %s: %s\n", j.hosts[cmp.Left], j.hosts[cmp.Right], cmp.Verdict)
+		if cmp.Verdict == VerdictStatusDiff {
+			fmt.Fprintf(&b, "    status code %d != %d\n", r.Hosts[cmp.Left].StatusCode, r.Hosts[cmp.Right].StatusCode)
+		}
+
+		for _, d := range cmp.Headers {
+			describeDiff(&b, "header "+d.Path, d)
+		}
+
+		for _, d := range cmp.Diffs {
+			describeDiff(&b, d.Path, d)
+		}
+	}
+
+	return b.String()
+}
+
+func describeDiff(b *strings.Builder, path string, d Difference) {
+	diff := newReportDiff(path, d)
+	fmt.Fprintf(b, "    %s %s: %s != %s\n", diff.Type, diff.Path, diff.Left, diff.Right)
+}
+
+// requestName identifies the request of a result by its method and rel url. eg: GET /v1/cards/1
+func requestName(r Result) string {
+	if r.Method == "" {
+		return r.RelURL
+	}
+
+	return r.Method + " " + r.RelURL
+}
+
+// elapsed returns the time it took the slowest host to respond.
+func elapsed(r Result) time.Duration {
+	var max time.Duration
+	for _, h := range r.Hosts {
+		if h.Elapsed > max {
+			max = h.Elapsed
+		}
+	}
+
+	return max
+}
+
+func withoutBodies(hosts []Host) []Host {
+	result := make([]Host, len(hosts))
+	for i, h := range hosts {
+		h.Body = nil
+		result[i] = h
+	}
+
+	return result
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func junitResults() []Result {
+	return []Result{
+		{RelURL: "/v1/cards/1", Method: http.MethodGet, Verdict: VerdictEqual,
+			Hosts: []Host{{StatusCode: 200, Elapsed: 10 * time.Millisecond}, {StatusCode: 200, Elapsed: 20 * time.Millisecond}}},
+		{RelURL: "/v1/cards/2", Method: http.MethodGet, Verdict: VerdictBodyDiff,
+			Hosts: []Host{{StatusCode: 200, Elapsed: 10 * time.Millisecond}, {StatusCode: 200, Elapsed: 5 * time.Millisecond}},
+			Comparisons: []Comparison{{Left: 0, Right: 1, Verdict: VerdictBodyDiff, Diffs: []Difference{
+				{Type: ValueMismatch, Path: "name", Left: "visa", Right: "master"},
+			}}}},
+		{RelURL: "/v1/users/1", Method: http.MethodGet, Verdict: VerdictError, Errors: []error{errors.New("connection refused")}},
+	}
+}
+
+func TestJUnitReportByURL(t *testing.T) {
+	j, err := NewJUnitReport([]string{"http://host1.com", "http://host2.com"}, JUnitGroupURL)
+	assert.NoError(t, err)
+	defer j.Close()
+	for _, r := range junitResults() {
+		j.Record(r)
+	}
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, j.Write(out))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1" errors="1" time="0.030">
+  <testsuite name="gomparator http://host1.com http://host2.com" tests="3" failures="1" errors="1" time="0.030">
+    <testcase name="GET /v1/cards/1" classname="/v1/cards/{id}" time="0.020"></testcase>
+    <testcase name="GET /v1/cards/2" classname="/v1/cards/{id}" time="0.010">
+      <failure message="body-diff" type="body-diff">  http://host1.com - http://host2.com: body-diff&#xA;    mismatch name: &#34;visa&#34; != &#34;master&#34;&#xA;</failure>
+    </testcase>
+    <testcase name="GET /v1/users/1" classname="/v1/users/{id}" time="0.000">
+      <error message="error" type="error">  connection refused&#xA;</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, out.String())
+}
+
+func TestJUnitReportByEndpoint(t *testing.T) {
+	j, err := NewJUnitReport([]string{"http://host1.com", "http://host2.com"}, JUnitGroupEndpoint)
+	assert.NoError(t, err)
+	for _, r := range junitResults() {
+		j.Record(r)
+	}
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, j.Write(out))
+	assert.Contains(t, out.String(), `<testsuites tests="2" failures="1" errors="1" time="0.030">`)
+	assert.Contains(t, out.String(), `<testcase name="/v1/cards/{id}" classname="gomparator" time="0.030">
+      <failure message="1 of 2 urls mismatched, 0 failed" type="mismatch">GET /v1/cards/2: body-diff&#xA;  http://host1.com - http://host2.com: body-diff&#xA;    mismatch name: &#34;visa&#34; != &#34;master&#34;&#xA;</failure>`)
+	assert.Contains(t, out.String(), `<error message="1 of 1 urls failed" type="error">GET /v1/users/1: error&#xA;  connection refused&#xA;</error>`)
+}
+
+func TestJUnitReportWithoutResults(t *testing.T) {
+	j, err := NewJUnitReport([]string{"http://host1.com", "http://host2.com"}, JUnitGroupURL)
+	assert.NoError(t, err)
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, j.Write(out))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="0" failures="0" errors="0" time="0.000">
+  <testsuite name="gomparator http://host1.com http://host2.com" tests="0" failures="0" errors="0" time="0.000">
+  </testsuite>
+</testsuites>
+`, out.String())
+
+	// The test cases are kept in a temporary file until the report is closed.
+	name := j.cases.Name()
+	assert.FileExists(t, name)
+	assert.NoError(t, j.Close())
+	_, err = os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestInvalidJUnitGroup(t *testing.T) {
+	_, err := NewJUnitReport([]string{"http://host1.com", "http://host2.com"}, "host")
+	assert.EqualError(t, err, `invalid junit group "host": expected url or endpoint`)
+}
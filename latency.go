@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minSlowerDelta is the minimum difference for a candidate host to be flagged as slower,
+// so endpoints that respond within a few milliseconds are not flagged by noise.
+const minSlowerDelta = 5 * time.Millisecond
+
+// idSegment matches the segments of a path that identify a resource, such as numeric ids or uuids.
+var idSegment = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// LatencyReport is a Recorder that collects the latency and time to first byte of every host, overall and by
+// endpoint group, to report their percentiles along with the endpoints in which a candidate host is slower than the baseline.
+type LatencyReport struct {
+	mu    sync.Mutex
+	hosts []string
+	// threshold is how much slower than the baseline a candidate must be to be flagged. eg: 0.2 for 20%
+	threshold float64
+	latency   []samples
+	ttfb      []samples
+	groups    map[string][]samples
+}
+
+// SlowEndpoint is an endpoint group in which a candidate host is slower than the baseline at the 90th percentile.
+type SlowEndpoint struct {
+	Endpoint  string
+	Host      int
+	Baseline  time.Duration
+	Candidate time.Duration
+}
+
+// samples holds the durations measured for a host.
+type samples []time.Duration
+
+func NewLatencyReport(hosts []string, threshold float64) *LatencyReport {
+	return &LatencyReport{
+		hosts:     hosts,
+		threshold: threshold,
+		latency:   make([]samples, len(hosts)),
+		ttfb:      make([]samples, len(hosts)),
+		groups:    make(map[string][]samples),
+	}
+}
+
+// ParseSlowerThreshold parses how much slower than the baseline a candidate must be, as a percentage. eg: 20%
+func ParseSlowerThreshold(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid slower threshold %q", s)
+	}
+
+	return v / 100, nil
+}
+
+// Record adds the latency of every host that responded. Results of a different number of hosts are ignored.
+func (l *LatencyReport) Record(r Result) {
+	if len(r.Hosts) != len(l.hosts) {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	group := endpointGroup(r.RelURL)
+	if _, ok := l.groups[group]; !ok {
+		l.groups[group] = make([]samples, len(l.hosts))
+	}
+
+	for i, h := range r.Hosts {
+		if h.Error != nil || h.URL == nil {
+			continue
+		}
+
+		// Retries are not measured, since their waits would hide the latency of the host.
+		latency := h.Latency
+		if latency == 0 {
+			latency = h.Elapsed
+		}
+
+		l.latency[i] = append(l.latency[i], latency)
+		l.groups[group][i] = append(l.groups[group][i], latency)
+		if h.TTFB > 0 {
+			l.ttfb[i] = append(l.ttfb[i], h.TTFB)
+		}
+	}
+}
+
+// Slower returns the endpoint groups in which a candidate host is slower than the baseline by more than the threshold
+// at the 90th percentile, sorted by endpoint.
+func (l *LatencyReport) Slower() []SlowEndpoint {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.slower()
+}
+
+func (l *LatencyReport) slower() []SlowEndpoint {
+	var slower []SlowEndpoint
+	for _, group := range l.sortedGroups() {
+		s := l.groups[group]
+		if len(s[0]) == 0 {
+			continue
+		}
+
+		baseline := s[0].percentile(90)
+		for i := 1; i < len(s); i++ {
+			if len(s[i]) == 0 {
+				continue
+			}
+
+			candidate := s[i].percentile(90)
+			if candidate-baseline >= minSlowerDelta && float64(candidate) > float64(baseline)*(1+l.threshold) {
+				slower = append(slower, SlowEndpoint{Endpoint: group, Host: i, Baseline: baseline, Candidate: candidate})
+			}
+		}
+	}
+
+	return slower
+}
+
+// Print writes the latency percentiles of every host, overall and by endpoint group, followed by the slower endpoints.
+func (l *LatencyReport) Print(w io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fmt.Fprintln(w, "latency")
+	for i, host := range l.hosts {
+		l.latency[i].print(w, "  "+host)
+		if len(l.ttfb[i]) > 0 {
+			l.ttfb[i].print(w, "  "+host+" ttfb")
+		}
+	}
+
+	fmt.Fprintln(w, "latency by endpoint")
+	for _, group := range l.sortedGroups() {
+		fmt.Fprintf(w, "  %s\n", group)
+		for i, host := range l.hosts {
+			l.groups[group][i].print(w, "    "+host)
+		}
+	}
+
+	slower := l.slower()
+	if len(slower) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "slower endpoints (p90 more than %.0f%% over the baseline)\n", l.threshold*100)
+	for _, s := range slower {
+		fmt.Fprintf(w, "  %s %s: %s - %s: %s\n", s.Endpoint,
+			l.hosts[0], formatDuration(s.Baseline), l.hosts[s.Host], formatDuration(s.Candidate))
+	}
+}
+
+func (l *LatencyReport) sortedGroups() []string {
+	groups := make([]string, 0, len(l.groups))
+	for group := range l.groups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	return groups
+}
+
+func (s samples) print(w io.Writer, label string) {
+	if len(s) == 0 {
+		fmt.Fprintf(w, "%s: no responses\n", label)
+
+		return
+	}
+
+	fmt.Fprintf(w, "%s: p50 %s, p90 %s, p99 %s\n", label,
+		formatDuration(s.percentile(50)), formatDuration(s.percentile(90)), formatDuration(s.percentile(99)))
+}
+
+// percentile returns the nearest rank percentile of the samples, which must not be empty.
+func (s samples) percentile(p float64) time.Duration {
+	sorted := make(samples, len(s))
+	copy(sorted, s)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+
+	return sorted[rank]
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
+
+// endpointGroup returns the path of a rel url with the segments that identify a resource replaced by {id},
+// so the latency of every call to the same endpoint is grouped together. eg: /v1/cards/{id}
+func endpointGroup(relURL string) string {
+	u, err := url.Parse(relURL)
+	if err != nil {
+		return relURL
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i, s := range segments {
+		if idSegment.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeLatencyResult(relURL string, elapsed ...time.Duration) Result {
+	r := Result{RelURL: relURL}
+	for _, e := range elapsed {
+		r.Hosts = append(r.Hosts, Host{URL: &url.URL{Path: relURL}, Elapsed: e, TTFB: e / 2})
+	}
+
+	return r
+}
+
+func TestLatencyReportExcludesRetries(t *testing.T) {
+	l := NewLatencyReport([]string{"http://host1.com", "http://host2.com"}, 0.2)
+
+	// The candidate was retried, so it took a whole second although its last attempt was as fast as the baseline.
+	r := makeLatencyResult("/v1/cards/1", 10*time.Millisecond, time.Second)
+	r.Hosts[1].Latency = 10 * time.Millisecond
+	l.Record(r)
+
+	assert.Empty(t, l.Slower())
+	assert.Equal(t, 10*time.Millisecond, l.latency[1].percentile(50))
+}
+
+func TestPercentile(t *testing.T) {
+	var s samples
+	for i := 10; i >= 1; i-- {
+		s = append(s, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 5*time.Millisecond, s.percentile(50))
+	assert.Equal(t, 9*time.Millisecond, s.percentile(90))
+	assert.Equal(t, 10*time.Millisecond, s.percentile(99))
+	assert.Equal(t, 7*time.Millisecond, samples{7 * time.Millisecond}.percentile(50))
+}
+
+func TestEndpointGroup(t *testing.T) {
+	assert.Equal(t, "/v1/cards/{id}", endpointGroup("/v1/cards/123?site=MLA"))
+	assert.Equal(t, "/v1/users/{id}/cards", endpointGroup("/v1/users/123e4567-e89b-12d3-a456-426614174000/cards"))
+	assert.Equal(t, "/v1/cards", endpointGroup("/v1/cards"))
+}
+
+func TestLatencyReport(t *testing.T) {
+	l := NewLatencyReport([]string{"http://host1.com", "http://host2.com", "http://host3.com"}, 0.2)
+
+	for i := 1; i <= 10; i++ {
+		ms := time.Duration(i) * time.Millisecond
+		l.Record(makeLatencyResult("/v1/cards/1", 10*ms, 11*ms, 20*ms))
+		l.Record(makeLatencyResult("/v1/payments", 10*ms, 30*ms, 10*ms))
+	}
+
+	failed := makeLatencyResult("/v1/payments", time.Second, time.Second, time.Second)
+	failed.Hosts[1].Error = errors.New("timeout")
+	l.Record(failed)
+
+	assert.Equal(t, []SlowEndpoint{
+		{Endpoint: "/v1/cards/{id}", Host: 2, Baseline: 90 * time.Millisecond, Candidate: 180 * time.Millisecond},
+		{Endpoint: "/v1/payments", Host: 1, Baseline: 100 * time.Millisecond, Candidate: 270 * time.Millisecond},
+	}, l.Slower())
+
+	var buf bytes.Buffer
+	l.Print(&buf)
+	// The host which failed is not measured.
+	assert.Contains(t, buf.String(), "  http://host2.com ttfb: p50 44.0ms, p90 120.0ms, p99 150.0ms\n")
+	assert.Contains(t, buf.String(), "  /v1/payments\n    http://host1.com: p50 60.0ms, p90 100.0ms, p99 1000.0ms\n")
+	assert.Contains(t, buf.String(), "  /v1/payments http://host1.com: 100.0ms - http://host2.com: 270.0ms\n")
+}
+
+func TestParseSlowerThreshold(t *testing.T) {
+	v, err := ParseSlowerThreshold("20%")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.2, v)
+
+	_, err = ParseSlowerThreshold("fast")
+	assert.Error(t, err)
+}
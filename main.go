@@ -7,7 +7,9 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -31,13 +33,19 @@ func newApp() *cli.App {
 	app.Version = "1.9.3"
 
 	app.Flags = []cli.Flag{
+		configFlag(),
 		&cli.StringFlag{
 			Name:  "path",
 			Usage: "specifies the file from which to read targets. It should contain one column only with a rel path. eg: /v1/cards?query=123",
 		},
+		&cli.StringFlag{
+			Name:  "format",
+			Value: FormatText,
+			Usage: "format of the file specified in path. Either text, with one rel path per line, or jsonl, with one json request per line. eg: {\"method\":\"POST\",\"path\":\"/v1/payments\",\"headers\":{},\"body\":{}}",
+		},
 		&cli.StringSliceFlag{
 			Name:  "host",
-			Usage: "targeted hosts. Exactly 2 must be specified. eg: --host 'http://host1.com --host 'http://host2.com'",
+			Usage: "targeted hosts. At least 2 must be specified, being the first one the baseline. eg: --host 'http://host1.com --host 'http://host2.com'",
 		},
 		&cli.StringSliceFlag{
 			Name:    "header",
@@ -56,10 +64,6 @@ func newApp() *cli.App {
 			Value:   1,
 			Usage:   "number of workers running concurrently",
 		},
-		&cli.BoolFlag{
-			Name:  "status-code-only",
-			Usage: "whether or not it only compares status code ignoring response body",
-		},
 		&cli.DurationFlag{
 			Name:  "timeout",
 			Value: DefaultTimeout,
@@ -72,16 +76,313 @@ func newApp() *cli.App {
 			Usage:   "duration of the comparison [0 = forever]",
 		},
 		&cli.StringFlag{
-			Name:  "exclude",
-			Usage: "excludes a value from both json for the specified path. A path is a series of keys separated by a dot or #",
+			Name:  "checkpoint",
+			Usage: "specifies the file in which to save the progress of the run periodically, so it can be resumed with --resume",
+		},
+		&cli.DurationFlag{
+			Name:  "checkpoint-interval",
+			Value: DefaultCheckpointInterval,
+			Usage: "how often the progress of the run is saved to the checkpoint",
+		},
+		&cli.StringFlag{
+			Name:  "resume",
+			Usage: "resumes the run saved in the given checkpoint, skipping the lines already compared and appending to the output. Progress keeps being saved to it unless --checkpoint is given. The latency, html and junit reports only cover the lines compared after resuming",
+		},
+		slowerThresholdFlag(),
+		&cli.BoolFlag{
+			Name:  "detect-noise",
+			Usage: "fetches the baseline host twice for GET, HEAD and OPTIONS requests to detect non deterministic json paths, ignoring them when comparing against the rest of the hosts",
+		},
+		&cli.BoolFlag{
+			Name:  "ci",
+			Usage: "runs in non interactive mode, without progress bar, exiting with a non zero status code when any threshold is exceeded",
+		},
+		&cli.StringFlag{
+			Name:  "max-mismatches",
+			Value: "0",
+			Usage: "maximum number of status code, body or header mismatches allowed in ci mode, either as an absolute count or a percentage. eg: 10 or 2.5%",
+		},
+		&cli.StringFlag{
+			Name:  "max-errors",
+			Value: "0",
+			Usage: "maximum number of errors allowed in ci mode, either as an absolute count or a percentage. eg: 10 or 2.5%",
 		},
 	}
+	app.Flags = append(app.Flags, hostFlags()...)
+	app.Flags = append(app.Flags, oauth2Flags()...)
+	app.Flags = append(app.Flags, comparisonFlags()...)
 
 	app.Action = action
+	app.Commands = []*cli.Command{
+		newProxyCommand(),
+		newRecordCommand(),
+		newCompareSnapshotsCommand(),
+		newRerunCommand(),
+	}
 
 	return app
 }
 
+// slowerThresholdFlag returns the flag which specifies when a candidate host is flagged as slower than the baseline.
+func slowerThresholdFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "slower-threshold",
+		Value: "20%",
+		Usage: "percentage by which the p90 latency of a candidate host must exceed the baseline's on an endpoint to be reported as slower",
+	}
+}
+
+// hostFlags returns the flags that configure the headers, query params and credentials sent only to one of the hosts.
+func hostFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "host-header",
+			Usage: "header to be used in the http call to a single host, given by its index or left and right. eg: left:X-Auth-Token:abc",
+		},
+		&cli.StringSliceFlag{
+			Name:  "host-query",
+			Usage: "query param to be added to the rel urls of a single host, given by its index or left and right. eg: right:api_key=xyz",
+		},
+		&cli.StringSliceFlag{
+			Name:  "host-auth",
+			Usage: "credentials for the basic authentication of a single host, given by its index or left and right. eg: right:admin:secret",
+		},
+	}
+}
+
+// oauth2Flags returns the flags that configure the client credentials from which to obtain a bearer token for every request.
+func oauth2Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "oauth2-token-url",
+			Usage: "oauth2 endpoint from which to obtain a token using the client credentials grant, sent as a bearer token in every http call and refreshed before it expires",
+		},
+		&cli.StringFlag{
+			Name:  "oauth2-client-id",
+			Usage: "client id used to obtain oauth2 tokens",
+		},
+		&cli.StringFlag{
+			Name:    "oauth2-client-secret",
+			EnvVars: []string{"GOMPARATOR_OAUTH2_CLIENT_SECRET"},
+			Usage:   "client secret used to obtain oauth2 tokens",
+		},
+		&cli.StringSliceFlag{
+			Name:  "oauth2-scope",
+			Usage: "scope requested for the oauth2 tokens",
+		},
+	}
+}
+
+// comparisonFlags returns the flags that configure how responses are compared and reported,
+// shared by every command that compares hosts.
+func comparisonFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "status-code-only",
+			Usage: "whether or not it only compares status code ignoring response body",
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "excludes a value from both json for the specified path. A path is a series of keys separated by a dot or #. It can be scoped to the rel urls matching a pattern. eg: /v1/cards*:results.#.id",
+		},
+		&cli.StringFlag{
+			Name:  "exclude-file",
+			Usage: "specifies a file from which to read exclusion rules, one per line, with the same syntax as exclude",
+		},
+		&cli.StringSliceFlag{
+			Name:  "match",
+			Usage: "validates the values at the specified path with a matcher instead of comparing them: type, uuid, iso8601 or regex:expression, optionally prefixed with right: to skip the baseline. eg: results.#.created_at=iso8601",
+		},
+		&cli.StringSliceFlag{
+			Name:  "match-key",
+			Usage: "pairs the elements of the array at the specified path by the value of a key instead of by their position. eg: items.#=id",
+		},
+		&cli.BoolFlag{
+			Name:  "strict-order",
+			Usage: "compares the elements of every array in order, unless overridden by array-order",
+		},
+		&cli.StringSliceFlag{
+			Name:  "array-order",
+			Usage: "sets whether the array at the specified path is compared in order or not. eg: results=ordered or tags=unordered",
+		},
+		&cli.StringSliceFlag{
+			Name:  "tolerance",
+			Usage: "allows numbers to differ up to an absolute or relative amount, for every path or a given one. eg: 0.000001, price=0.01 or items.#.price=1%",
+		},
+		&cli.StringSliceFlag{
+			Name:  "compare-header",
+			Usage: "compares the response header with the specified name, along with the status code and body. eg: Cache-Control",
+		},
+		&cli.BoolFlag{
+			Name:  "compare-all-headers",
+			Usage: "compares every response header but the ones that change on every response, such as Date, unless ignored with ignore-header",
+		},
+		&cli.StringSliceFlag{
+			Name:  "ignore-header",
+			Usage: "skips the response header with the specified name when comparing headers. eg: X-Request-Id",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "specifies the file in which to write the result of every comparison as a json object per line",
+		},
+		&cli.StringFlag{
+			Name:  "report",
+			Usage: "specifies the file in which to write a self-contained html report with the summary and the differences of every mismatched url",
+		},
+		&cli.StringFlag{
+			Name:  "junit",
+			Usage: "specifies the file in which to write a junit xml report, with mismatches as failures and errors as errors",
+		},
+		&cli.StringFlag{
+			Name:  "junit-group",
+			Value: JUnitGroupURL,
+			Usage: "what each test case of the junit report is. Either url, for every url compared, or endpoint, for every endpoint group. eg: /v1/cards/{id}",
+		},
+	}
+}
+
+// resultOutputs holds the recorders of the files in which the results of a comparison are written, given with
+// --output, --report and --junit. Each of them is nil unless requested.
+type resultOutputs struct {
+	file       *os.File
+	results    *ResultWriter
+	report     *HTMLReport
+	reportFile string
+	junit      *JUnitReport
+	junitFile  string
+}
+
+// parseOutputs returns the outputs requested, opening the file in which to write the results with open.
+// It must be closed once the outputs are written.
+func parseOutputs(c *cli.Context, hosts []string, open func(path string) (*os.File, error)) (*resultOutputs, error) {
+	o := &resultOutputs{reportFile: c.String("report"), junitFile: c.String("junit")}
+	if output := c.String("output"); output != "" {
+		f, err := open(output)
+		if err != nil {
+			return nil, err
+		}
+
+		o.file = f
+		o.results = NewResultWriter(f)
+	}
+
+	if o.reportFile != "" {
+		o.report = NewHTMLReport(hosts)
+	}
+
+	if o.junitFile != "" {
+		junit, err := NewJUnitReport(hosts, c.String("junit-group"))
+		if err != nil {
+			o.Close()
+
+			return nil, err
+		}
+		o.junit = junit
+	}
+
+	return o, nil
+}
+
+// recorders returns the recorders of the outputs requested.
+func (o *resultOutputs) recorders() []Recorder {
+	var recorders []Recorder
+	if o.results != nil {
+		recorders = append(recorders, o.results)
+	}
+
+	if o.report != nil {
+		recorders = append(recorders, o.report)
+	}
+
+	if o.junit != nil {
+		recorders = append(recorders, o.junit)
+	}
+
+	return recorders
+}
+
+// write writes the reports requested, returning the first error found while writing any of the outputs.
+func (o *resultOutputs) write() error {
+	if o.results != nil {
+		if err := o.results.Err(); err != nil {
+			return err
+		}
+	}
+
+	if o.report != nil {
+		if err := o.report.WriteFile(o.reportFile); err != nil {
+			return err
+		}
+	}
+
+	if o.junit != nil {
+		if err := o.junit.WriteFile(o.junitFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close releases the files held by the outputs.
+func (o *resultOutputs) Close() {
+	if o.file != nil {
+		o.file.Close()
+	}
+
+	if o.junit != nil {
+		o.junit.Close()
+	}
+}
+
+func parseSlowerThreshold(c *cli.Context) float64 {
+	threshold, err := ParseSlowerThreshold(c.String("slower-threshold"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return threshold
+}
+
+// parseHeaderRules returns the rules to compare response headers, or nil if they must not be compared.
+func parseHeaderRules(c *cli.Context) *HeaderRules {
+	return NewHeaderRules(c.Bool("compare-all-headers"), c.StringSlice("compare-header"), c.StringSlice("ignore-header"))
+}
+
+// parseComparator returns a Comparator configured with the comparison flags.
+func parseComparator(c *cli.Context) *Comparator {
+	var opts []func(*Comparator)
+	for _, s := range c.StringSlice("match-key") {
+		opt, err := ParseMatchKey(s)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, opt)
+	}
+
+	if c.Bool("strict-order") {
+		opts = append(opts, StrictOrder())
+	}
+
+	for _, s := range c.StringSlice("array-order") {
+		opt, err := ParseArrayOrder(s)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, opt)
+	}
+
+	for _, s := range c.StringSlice("tolerance") {
+		opt, err := ParseTolerance(s)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, opt)
+	}
+
+	return NewComparator(opts...)
+}
+
 func initLogger() {
 	log.SetFormatter(&log.TextFormatter{
 		TimestampFormat: "2006-01-02 15:04:05",
@@ -93,62 +394,177 @@ func initLogger() {
 }
 
 type options struct {
-	filePath       string
-	hosts          []string
-	headers        []string
-	timeout        time.Duration
-	duration       time.Duration
-	workers        int
-	rateLimit      int
-	statusCodeOnly bool
-	maxBody        int64
-	exclude        string
+	filePath           string
+	format             string
+	hosts              []string
+	headers            []string
+	timeout            time.Duration
+	duration           time.Duration
+	workers            int
+	rateLimit          int
+	statusCodeOnly     bool
+	maxBody            int64
+	hostOptions        []HostOptions
+	tokens             *TokenSource
+	excludes           []ExclusionRule
+	matchers           []MatcherRule
+	headerRules        *HeaderRules
+	slower             float64
+	comparator         *Comparator
+	checkpoint         string
+	resume             string
+	checkpointInterval time.Duration
+	detectNoise        bool
+	ci                 bool
+	maxMismatches      Threshold
+	maxErrors          Threshold
 }
 
 func action(c *cli.Context) error {
+	if err := applyConfig(c); err != nil {
+		return err
+	}
+
 	opts := parseFlags(c)
 	headers := parseHeaders(opts.headers)
 
-	fetcher := NewHTTPClient(
-		Timeout(opts.timeout),
-		MaxBody(opts.maxBody))
+	clientOpts := []func(*Client){Timeout(opts.timeout), MaxBody(opts.maxBody)}
+	if opts.tokens != nil {
+		clientOpts = append(clientOpts, OAuth2(opts.tokens))
+	}
+	fetcher := NewHTTPClient(clientOpts...)
 
-	ctx, cancel := createContext(opts)
+	ctx, stop, cancel := createContext(opts)
 	defer cancel()
 
 	file := openFile(opts)
 	defer file.Close()
 
+	// The checkpoint is opened before logging to the temp file, so an invalid one is reported to the user.
+	checkpoint, err := openCheckpoint(opts)
+	if err != nil {
+		return err
+	}
+
 	logFile := createTmpFile()
 	defer logFile.Close()
 
 	log.Printf("created log temp file in %s", logFile.Name())
 	log.SetOutput(logFile)
 
-	lines := getTotalLines(file)
+	summary := NewSummary(opts.hosts)
+	latency := NewLatencyReport(opts.hosts, opts.slower)
+	recorders := []Recorder{summary, latency}
+
+	// The lines are counted even without a progress bar, so a file that cannot be read whole fails before
+	// comparing any of it.
+	lines, err := getTotalLines(file)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("could not read %s: %v", opts.filePath, err), 1)
+	}
+
 	// Once we count the number of lines that will be used as total for the progress bar we reset
 	// the pointer to the beginning of the file since it is much faster than closing and reopening
-	_, err := file.Seek(0, 0)
-	if err != nil {
+	if _, err := file.Seek(0, 0); err != nil {
 		return err
 	}
 
-	bar := NewProgressBar(lines)
-	bar.Start()
+	var bar *ProgressBar
+	if !opts.ci {
+		if checkpoint != nil {
+			lines -= checkpoint.Total()
+		}
+
+		bar = NewProgressBar(lines)
+		recorders = append(recorders, bar)
+	}
+
+	// When resuming, the results written after the checkpoint was saved are discarded.
+	open := os.Create
+	if checkpoint != nil {
+		open = checkpoint.OpenOutput
+	}
 
-	reader := NewReader(file, opts.hosts)
-	producer := NewProducer(opts.workers, headers,
-		ratelimit.New(opts.rateLimit), fetcher)
-	comparator := NewConsumer(opts.statusCodeOnly, bar, log.StandardLogger(), opts.exclude)
+	outputs, err := parseOutputs(c, opts.hosts, open)
+	if err != nil {
+		return cli.Exit(err, 1)
+	}
+	defer outputs.Close()
+	recorders = append(recorders, outputs.recorders()...)
+
+	var reader Reader = NewReader(file, opts.hosts, opts.format)
+	if checkpoint != nil {
+		checkpoint.RestoreSummary(summary)
+		reader = NewSkipReader(reader, checkpoint.Done)
+		// The checkpoint goes last so a line is not marked as compared before its result is written.
+		recorders = append(recorders, checkpoint)
+	}
+
+	if bar != nil {
+		bar.Start()
+	}
+
+	producer := NewProducer(opts.workers, headers, opts.hostOptions,
+		ratelimit.New(opts.rateLimit), fetcher, opts.detectNoise)
+	comparator := NewConsumer(opts.statusCodeOnly, log.StandardLogger(), opts.excludes, opts.matchers, opts.headerRules,
+		opts.comparator, recorders...)
 	p := New(reader, producer, comparator)
 
-	p.Run(ctx)
-	bar.Stop()
+	p.RunUntil(ctx, stop)
+	if bar != nil {
+		bar.Stop()
+	}
+
+	interrupted := stop.Err() != nil
+	if interrupted {
+		fmt.Println("interrupted: showing the results of the requests processed so far")
+	}
+
+	summary.Print(os.Stdout)
+	latency.Print(os.Stdout)
+
+	if err := outputs.write(); err != nil {
+		return cli.Exit(err, 1)
+	}
+
+	if checkpoint != nil {
+		if err := checkpoint.Save(); err != nil {
+			return cli.Exit(err, 1)
+		}
+	}
+
+	if interrupted {
+		if checkpoint != nil {
+			fmt.Printf("progress saved, resume with --resume %s\n", checkpoint.file)
+		}
+
+		return cli.Exit("interrupted", 130)
+	}
+
+	if opts.ci {
+		return checkThresholds(opts, summary)
+	}
 
 	return nil
 }
 
-func createContext(opts *options) (context.Context, context.CancelFunc) {
+// checkThresholds returns an error with a non zero exit code if the summary exceeds any of the thresholds.
+func checkThresholds(opts *options, summary *Summary) error {
+	total := summary.Total()
+	if n := summary.Mismatches(); opts.maxMismatches.Exceeded(n, total) {
+		return cli.Exit(fmt.Sprintf("mismatches threshold exceeded: %d of %d, max %s", n, total, opts.maxMismatches), 1)
+	}
+
+	if n := summary.Errors(); opts.maxErrors.Exceeded(n, total) {
+		return cli.Exit(fmt.Sprintf("errors threshold exceeded: %d of %d, max %s", n, total, opts.maxErrors), 1)
+	}
+
+	return nil
+}
+
+// createContext returns the context of the run along with the one done once the run is interrupted by SIGINT or
+// SIGTERM, so the pairs in flight can be drained before finishing. A second signal cancels the run right away.
+func createContext(opts *options) (context.Context, context.Context, context.CancelFunc) {
 	var ctx context.Context
 	var cancel context.CancelFunc
 	t := opts.duration
@@ -160,7 +576,60 @@ func createContext(opts *options) (context.Context, context.CancelFunc) {
 		ctx, cancel = context.WithTimeout(context.Background(), t)
 	}
 
-	return ctx, cancel
+	stop, interrupt := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			interrupt()
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, stop, func() {
+		signal.Stop(signals)
+		interrupt()
+		cancel()
+	}
+}
+
+// openCheckpoint returns the checkpoint in which to save the progress of the run, continuing from the one being
+// resumed, if any. It returns nil if progress must not be saved.
+func openCheckpoint(opts *options) (*Checkpoint, error) {
+	file := opts.checkpoint
+	if file == "" {
+		file = opts.resume
+	}
+
+	if file == "" {
+		return nil, nil
+	}
+
+	checkpoint := NewCheckpoint(file, opts.filePath, opts.hosts, opts.checkpointInterval)
+	if opts.resume == "" {
+		return checkpoint, nil
+	}
+
+	f, err := os.Open(opts.resume)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if err := checkpoint.Resume(f); err != nil {
+		return nil, fmt.Errorf("could not resume from %s: %v", opts.resume, err)
+	}
+
+	return checkpoint, nil
 }
 
 func openFile(opts *options) *os.File {
@@ -182,8 +651,8 @@ func createTmpFile() *os.File {
 	return logFile
 }
 
-func getTotalLines(reader io.Reader) int {
-	scanner := bufio.NewScanner(reader)
+func getTotalLines(reader io.Reader) (int, error) {
+	scanner := newScanner(reader)
 
 	// Set the split function for the scanning operation.
 	scanner.Split(bufio.ScanLines)
@@ -194,18 +663,28 @@ func getTotalLines(reader io.Reader) int {
 		count++
 	}
 
-	return count
+	if err := scanner.Err(); err != nil {
+		return count, fmt.Errorf("line %d: %v", count+1, err)
+	}
+
+	return count, nil
 }
 
 func parseFlags(c *cli.Context) *options {
 	opts := &options{}
 
-	if opts.hosts = c.StringSlice("host"); len(opts.hosts) != 2 {
+	if opts.hosts = c.StringSlice("host"); len(opts.hosts) < 2 {
 		log.Fatal("invalid number of hosts provided")
 	}
 
 	opts.filePath = c.String("path")
+	if opts.format = c.String("format"); opts.format != FormatText && opts.format != FormatJSONL {
+		log.Fatalf("invalid format provided: %s", opts.format)
+	}
+
 	opts.headers = c.StringSlice("header")
+	opts.hostOptions = parseHostOptions(c, len(opts.hosts))
+	opts.tokens = parseTokenSource(c)
 	opts.timeout = c.Duration("timeout")
 	opts.duration = c.Duration("duration")
 	opts.workers = c.Int("workers")
@@ -216,11 +695,108 @@ func parseFlags(c *cli.Context) *options {
 	} else {
 		opts.maxBody = DefaultMaxBody
 	}
-	opts.exclude = c.String("exclude")
+	opts.excludes = parseExclusionRules(c.StringSlice("exclude"), c.String("exclude-file"))
+	opts.matchers = parseMatcherRules(c.StringSlice("match"))
+	opts.headerRules = parseHeaderRules(c)
+	opts.slower = parseSlowerThreshold(c)
+	opts.comparator = parseComparator(c)
+	opts.checkpoint = c.String("checkpoint")
+	opts.checkpointInterval = c.Duration("checkpoint-interval")
+	opts.resume = c.String("resume")
+	opts.detectNoise = c.Bool("detect-noise")
+	opts.ci = c.Bool("ci")
+
+	var err error
+	if opts.maxMismatches, err = ParseThreshold(c.String("max-mismatches")); err != nil {
+		log.Fatal(err)
+	}
+
+	if opts.maxErrors, err = ParseThreshold(c.String("max-errors")); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := validateJUnitGroup(c.String("junit-group")); err != nil {
+		log.Fatal(err)
+	}
 
 	return opts
 }
 
+func parseExclusionRules(excludes []string, file string) []ExclusionRule {
+	rules := make([]ExclusionRule, 0, len(excludes))
+	for _, e := range excludes {
+		rule, err := ParseExclusionRule(e)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if file == "" {
+		return rules
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	fileRules, err := ReadExclusionRules(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return append(rules, fileRules...)
+}
+
+func parseMatcherRules(matchers []string) []MatcherRule {
+	rules := make([]MatcherRule, 0, len(matchers))
+	for _, m := range matchers {
+		rule, err := ParseMatcherRule(m)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+func parseHostOptions(c *cli.Context, hosts int) []HostOptions {
+	options, err := ParseHostOptions(hosts, c.StringSlice("host-header"), c.StringSlice("host-query"), c.StringSlice("host-auth"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return options
+}
+
+// parseTokenSource returns the source of oauth2 tokens, or nil if no token url was given.
+func parseTokenSource(c *cli.Context) *TokenSource {
+	tokenURL := c.String("oauth2-token-url")
+	if tokenURL == "" {
+		return nil
+	}
+
+	clientID := c.String("oauth2-client-id")
+	if clientID == "" {
+		log.Fatal("oauth2 client id must be specified")
+	}
+
+	return NewTokenSource(tokenURL, clientID, c.String("oauth2-client-secret"), c.StringSlice("oauth2-scope"))
+}
+
+// parseHTTPClient returns a Client with the timeout and oauth2 flags of the command, along with the given options.
+func parseHTTPClient(c *cli.Context, opts ...func(*Client)) *Client {
+	opts = append(opts, Timeout(c.Duration("timeout")))
+	if tokens := parseTokenSource(c); tokens != nil {
+		opts = append(opts, OAuth2(tokens))
+	}
+
+	return NewHTTPClient(opts...)
+}
+
 func parseHeaders(h []string) map[string]string {
 	result := make(map[string]string, len(h))
 
@@ -229,7 +805,7 @@ func parseHeaders(h []string) map[string]string {
 			continue
 		}
 
-		h := strings.Split(header, ":")
+		h := strings.SplitN(header, ":", 2)
 		if len(h) != 2 {
 			log.Fatal("invalid header")
 		}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	uuidPattern    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	iso8601Pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}(T\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}(:?\d{2})?)?)?$`)
+)
+
+// Matcher validates the shape of a value instead of its exact content.
+type Matcher struct {
+	name  string
+	match func(v interface{}) bool
+	// placeholder is the value compared in place of the matched one.
+	placeholder func(v interface{}) interface{}
+}
+
+// MatcherRule validates the values at Path with its Matcher and then compares them by their shape only,
+// so volatile values such as ids or timestamps are still checked instead of silently dropped.
+// If the rule has a url pattern, it only applies to the rel urls matching it.
+type MatcherRule struct {
+	Path       string
+	URLPattern string
+	Matcher    Matcher
+	// RightOnly is whether the values of the baseline host are not validated.
+	RightOnly bool
+	pattern   *regexp.Regexp
+}
+
+// invalidValue is a value that does not satisfy a MatcherRule.
+type invalidValue struct {
+	Path  string
+	Value interface{}
+	Rule  string
+}
+
+// ParseMatcherRule parses a rule in the form of "path=matcher" or "url-pattern:path=matcher", with the same url
+// pattern syntax of the exclusion rules. The matcher is one of type, uuid, iso8601 or regex:expression, and can be
+// prefixed with right: to only validate the hosts compared against the baseline. eg: results.#.created_at=iso8601
+func ParseMatcherRule(s string) (MatcherRule, error) {
+	s = strings.TrimSpace(s)
+	var rule MatcherRule
+
+	spec := s
+	if strings.HasPrefix(spec, "/") {
+		index := strings.IndexRune(spec, ':')
+		if index == -1 {
+			return MatcherRule{}, fmt.Errorf("invalid matcher rule %q: missing path after url pattern", s)
+		}
+		rule.URLPattern = spec[:index]
+		rule.pattern = compileURLPattern(rule.URLPattern)
+		spec = spec[index+1:]
+	}
+
+	index := strings.IndexRune(spec, '=')
+	if index == -1 {
+		return MatcherRule{}, fmt.Errorf("invalid matcher rule %q: missing matcher", s)
+	}
+	rule.Path, spec = spec[:index], spec[index+1:]
+	if rule.Path == "" {
+		return MatcherRule{}, fmt.Errorf("invalid matcher rule %q: empty path", s)
+	}
+
+	if strings.HasPrefix(spec, "right:") {
+		rule.RightOnly = true
+		spec = strings.TrimPrefix(spec, "right:")
+	}
+
+	m, err := parseMatcher(spec)
+	if err != nil {
+		return MatcherRule{}, fmt.Errorf("invalid matcher rule %q: %v", s, err)
+	}
+	rule.Matcher = m
+
+	return rule, nil
+}
+
+func parseMatcher(s string) (Matcher, error) {
+	switch {
+	case s == "type":
+		return Matcher{
+			name:        s,
+			match:       func(interface{}) bool { return true },
+			placeholder: func(v interface{}) interface{} { return "<" + jsonType(v) + ">" },
+		}, nil
+	case s == "uuid":
+		return patternMatcher(s, uuidPattern), nil
+	case s == "iso8601":
+		return patternMatcher(s, iso8601Pattern), nil
+	case strings.HasPrefix(s, "regex:"):
+		re, err := regexp.Compile(strings.TrimPrefix(s, "regex:"))
+		if err != nil {
+			return Matcher{}, err
+		}
+
+		return patternMatcher(s, re), nil
+	default:
+		return Matcher{}, fmt.Errorf("unknown matcher %q", s)
+	}
+}
+
+// patternMatcher returns a Matcher of the strings, or numbers, matching the given regular expression.
+func patternMatcher(name string, re *regexp.Regexp) Matcher {
+	placeholder := "<" + name + ">"
+
+	return Matcher{
+		name: name,
+		match: func(v interface{}) bool {
+			switch t := v.(type) {
+			case string:
+				return re.MatchString(t)
+			case json.Number:
+				return re.MatchString(string(t))
+			default:
+				return false
+			}
+		},
+		placeholder: func(interface{}) interface{} { return placeholder },
+	}
+}
+
+// Matches reports whether the rule applies to the given rel url.
+func (m MatcherRule) Matches(relURL string) bool {
+	return m.pattern == nil || m.pattern.MatchString(relURL)
+}
+
+// apply replaces the values at the rule path with their placeholder and returns the ones that do not match,
+// unless validate is false.
+func (m MatcherRule) apply(j interface{}, validate bool) []invalidValue {
+	var invalid []invalidValue
+	Replace(j, m.Path, func(path string, v interface{}) interface{} {
+		if validate && !m.Matcher.match(v) {
+			invalid = append(invalid, invalidValue{Path: path, Value: v, Rule: m.Matcher.name})
+		}
+
+		return m.Matcher.placeholder(v)
+	})
+
+	return invalid
+}
+
+// jsonType returns the name of the json type of a Body-encoded value.
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMatcherRule(t *testing.T) {
+	rule, err := ParseMatcherRule("results.#.created_at=iso8601")
+	assert.NoError(t, err)
+	assert.Equal(t, "results.#.created_at", rule.Path)
+	assert.False(t, rule.RightOnly)
+	assert.True(t, rule.Matches("/v1/anything"))
+
+	rule, err = ParseMatcherRule("/v1/cards*:id=right:regex:^[a-z]+=[0-9]+$")
+	assert.NoError(t, err)
+	assert.Equal(t, "id", rule.Path)
+	assert.Equal(t, "/v1/cards*", rule.URLPattern)
+	assert.True(t, rule.RightOnly)
+	assert.True(t, rule.Matcher.match("abc=123"))
+	assert.False(t, rule.Matches("/v1/payments"))
+
+	for _, s := range []string{"id", "=uuid", "id=", "id=date", "id=regex:[", "/v1/cards"} {
+		_, err := ParseMatcherRule(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		matcher string
+		valid   []interface{}
+		invalid []interface{}
+	}{
+		{matcher: "type", valid: []interface{}{nil, "a", json.Number("1"), []interface{}{}}},
+		{
+			matcher: "uuid",
+			valid:   []interface{}{"123e4567-e89b-12d3-a456-426614174000"},
+			invalid: []interface{}{"123e4567", json.Number("1"), nil},
+		},
+		{
+			matcher: "iso8601",
+			valid:   []interface{}{"2020-01-02", "2020-01-02T03:04:05Z", "2020-01-02T03:04:05.123-03:00"},
+			invalid: []interface{}{"02/01/2020", "2020-01-02 03:04", true},
+		},
+		{
+			matcher: "regex:^[0-9]{3}$",
+			valid:   []interface{}{"123", json.Number("456")},
+			invalid: []interface{}{"12", map[string]interface{}{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.matcher, func(t *testing.T) {
+			m, err := parseMatcher(tt.matcher)
+			assert.NoError(t, err)
+
+			for _, v := range tt.valid {
+				assert.True(t, m.match(v), v)
+			}
+
+			for _, v := range tt.invalid {
+				assert.False(t, m.match(v), v)
+			}
+		})
+	}
+}
+
+func TestMatcherRuleApply(t *testing.T) {
+	rule, _ := ParseMatcherRule("items.#.id=uuid")
+	j, _ := Unmarshal([]byte(`{"items":[{"id":"123e4567-e89b-12d3-a456-426614174000"},{"id":"1"},{"name":"a"}]}`))
+
+	invalid := rule.apply(j, true)
+
+	assert.Equal(t, []invalidValue{{Path: "items.1.id", Value: "1", Rule: "uuid"}}, invalid)
+	assert.Equal(t, map[string]interface{}{"items": []interface{}{
+		map[string]interface{}{"id": "<uuid>"},
+		map[string]interface{}{"id": "<uuid>"},
+		map[string]interface{}{"name": "a"},
+	}}, j)
+
+	rule, _ = ParseMatcherRule("id=type")
+	j, _ = Unmarshal([]byte(`{"id":1}`))
+	assert.Empty(t, rule.apply(j, true))
+	assert.Equal(t, map[string]interface{}{"id": "<number>"}, j)
+}
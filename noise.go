@@ -0,0 +1,101 @@
+package main
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// noiseDetector learns which json paths are non deterministic for every endpoint by
+// comparing two responses of the baseline host, in the style of Twitter's Diffy.
+// Paths are kept normalized, so a path found noisy for an array element applies to all of them.
+type noiseDetector struct {
+	mu    sync.Mutex
+	paths map[string]map[string]bool
+}
+
+func newNoiseDetector() *noiseDetector {
+	return &noiseDetector{paths: make(map[string]map[string]bool)}
+}
+
+// learn records the paths of the differences found between two responses of the baseline host.
+func (n *noiseDetector) learn(endpoint string, diffs []Difference) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	paths, ok := n.paths[endpoint]
+	if !ok {
+		paths = make(map[string]bool)
+		n.paths[endpoint] = paths
+	}
+
+	var learned []string
+	for _, d := range diffs {
+		p := normalizePath(d.Path)
+		if !paths[p] {
+			paths[p] = true
+			learned = append(learned, p)
+		}
+	}
+
+	return learned
+}
+
+// filter removes the differences whose path, or any of its ancestors, is known to be noisy for the endpoint.
+func (n *noiseDetector) filter(endpoint string, diffs []Difference) []Difference {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	paths := n.paths[endpoint]
+	if len(paths) == 0 {
+		return diffs
+	}
+
+	var kept []Difference
+	for _, d := range diffs {
+		if !isNoise(paths, normalizePath(d.Path)) {
+			kept = append(kept, d)
+		}
+	}
+
+	return kept
+}
+
+// noise returns the noisy paths known so far for the endpoint.
+func (n *noiseDetector) noise(endpoint string) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	paths := make([]string, 0, len(n.paths[endpoint]))
+	for p := range n.paths[endpoint] {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func isNoise(paths map[string]bool, path string) bool {
+	for {
+		if paths[path] {
+			return true
+		}
+
+		index := strings.LastIndexByte(path, '.')
+		if index == -1 {
+			return path != "" && paths[""]
+		}
+		path = path[:index]
+	}
+}
+
+// endpoint returns the rel url without its query string so noise is shared among all the calls to the same resource.
+func endpoint(relURL string) string {
+	u, err := url.Parse(relURL)
+	if err != nil {
+		return relURL
+	}
+
+	return u.Path
+}
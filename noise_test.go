@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoiseDetector(t *testing.T) {
+	n := newNoiseDetector()
+	learned := n.learn("/v1/cards", []Difference{{Path: "request_id"}, {Path: "results.1.token"}})
+	assert.Equal(t, []string{"request_id", "results.#.token"}, learned)
+	assert.Empty(t, n.learn("/v1/cards", []Difference{{Path: "request_id"}}))
+
+	diffs := []Difference{
+		{Path: "request_id"},
+		{Path: "results.4.token.value"},
+		{Path: "results.4.amount"},
+	}
+	assert.Equal(t, []Difference{{Path: "results.4.amount"}}, n.filter("/v1/cards", diffs))
+	assert.Equal(t, diffs, n.filter("/v1/payments", diffs))
+	assert.Equal(t, []string{"request_id", "results.#.token"}, n.noise("/v1/cards"))
+}
@@ -5,11 +5,11 @@ import (
 )
 
 type Reader interface {
-	Read() <-chan URLPair
+	Read(ctx context.Context) <-chan URLPair
 }
 
 type Producer interface {
-	Produce(in <-chan URLPair) <-chan HostsPair
+	Produce(ctx context.Context, in <-chan URLPair) <-chan HostsPair
 }
 
 type Consumer interface {
@@ -30,9 +30,32 @@ type Pipeline struct {
 	consumer Consumer
 }
 
+// Run consumes the pairs of every url read until there are no more of them or the context is done,
+// in which case the pairs in flight are discarded.
 func (p *Pipeline) Run(ctx context.Context) {
-	readStream := p.reader.Read()
-	producerStream := p.producer.Produce(readStream)
+	p.run(ctx, ctx)
+}
+
+// RunUntil runs the pipeline like Run, except that once stop is done no more urls are read
+// but the pairs in flight are still produced and consumed, unless ctx is done as well.
+func (p *Pipeline) RunUntil(ctx, stop context.Context) {
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-stop.Done():
+			cancel()
+		case <-readCtx.Done():
+		}
+	}()
+
+	p.run(ctx, readCtx)
+}
+
+func (p *Pipeline) run(ctx, readCtx context.Context) {
+	readStream := p.reader.Read(readCtx)
+	producerStream := p.producer.Produce(ctx, readStream)
 
 	orDone := func(ctx context.Context, c <-chan HostsPair) <-chan HostsPair {
 		valStream := make(chan HostsPair)
@@ -60,4 +83,8 @@ func (p *Pipeline) Run(ctx context.Context) {
 	for val := range orDone(ctx, producerStream) {
 		p.consumer.Consume(val)
 	}
+
+	// Once cancelled, wait for the producer to release its workers, discarding the pairs still in flight.
+	for range producerStream {
+	}
 }
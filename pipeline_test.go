@@ -12,17 +12,22 @@ import (
 
 type readerStub struct{}
 
-func (*readerStub) Read() <-chan URLPair {
+func (*readerStub) Read(ctx context.Context) <-chan URLPair {
 	stream := make(chan URLPair)
 	go func() {
 		defer close(stream)
 
-		stream <- makeURLPair("hostA1", "hostA2")
-		stream <- makeURLPair("hostB1", "hostB2")
-		stream <- makeURLPair("hostC1", "hostC2")
-		stream <- makeURLPair("hostD1", "hostD2")
-		stream <- makeURLPair("hostE1", "hostE2")
-		stream <- makeURLPair("hostF1", "hostF2")
+		for _, host := range []string{"hostA", "hostB", "hostC", "hostD", "hostE", "hostF"} {
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case stream <- makeURLPair(host+"1", host+"2"):
+			case <-ctx.Done():
+				return
+			}
+		}
 	}()
 
 	return stream
@@ -36,34 +41,35 @@ func makeURLPair(leftHost, rightHost string) URLPair {
 	rightUrl.URL, rightUrl.Error = joinPath(fmt.Sprintf("http://%s.com", rightHost), "")
 
 	sleepRandom(200)
-	return URLPair{Left: leftUrl, Right: rightUrl}
+	return URLPair{URLs: []URL{leftUrl, rightUrl}}
 }
 
 type producerStub struct {
 	cancel        context.CancelFunc
 	toBeProcessed int
+	processed     int
 }
 
-func (p *producerStub) Produce(in <-chan URLPair) <-chan HostsPair {
+func (p *producerStub) Produce(ctx context.Context, in <-chan URLPair) <-chan HostsPair {
 	stream := make(chan HostsPair)
 	go func() {
 		defer close(stream)
 
-		var processed int
 		for val := range in {
-			if p.toBeProcessed > 0 && p.toBeProcessed == processed {
+			if p.toBeProcessed > 0 && p.toBeProcessed == p.processed {
 				p.cancel()
 				sleepRandom(200)
 			}
 			response := HostsPair{}
-			response.Left = Host{
-				URL: val.Left.URL,
+			for _, u := range val.URLs {
+				response.Hosts = append(response.Hosts, Host{URL: u.URL})
 			}
-			response.Right = Host{
-				URL: val.Right.URL,
+			select {
+			case stream <- response:
+			case <-ctx.Done():
+				return
 			}
-			stream <- response
-			processed++
+			p.processed++
 			sleepRandom(50)
 		}
 	}()
@@ -91,23 +97,23 @@ func TestRun(t *testing.T) {
 
 	assert.Equal(t, 6, consumer.times)
 
-	assert.Equal(t, "http://hostA1.com", consumer.responses[0].Left.URL.String())
-	assert.Equal(t, "http://hostA2.com", consumer.responses[0].Right.URL.String())
+	assert.Equal(t, "http://hostA1.com", consumer.responses[0].Hosts[0].URL.String())
+	assert.Equal(t, "http://hostA2.com", consumer.responses[0].Hosts[1].URL.String())
 
-	assert.Equal(t, "http://hostB1.com", consumer.responses[1].Left.URL.String())
-	assert.Equal(t, "http://hostB2.com", consumer.responses[1].Right.URL.String())
+	assert.Equal(t, "http://hostB1.com", consumer.responses[1].Hosts[0].URL.String())
+	assert.Equal(t, "http://hostB2.com", consumer.responses[1].Hosts[1].URL.String())
 
-	assert.Equal(t, "http://hostC1.com", consumer.responses[2].Left.URL.String())
-	assert.Equal(t, "http://hostC2.com", consumer.responses[2].Right.URL.String())
+	assert.Equal(t, "http://hostC1.com", consumer.responses[2].Hosts[0].URL.String())
+	assert.Equal(t, "http://hostC2.com", consumer.responses[2].Hosts[1].URL.String())
 
-	assert.Equal(t, "http://hostD1.com", consumer.responses[3].Left.URL.String())
-	assert.Equal(t, "http://hostD2.com", consumer.responses[3].Right.URL.String())
+	assert.Equal(t, "http://hostD1.com", consumer.responses[3].Hosts[0].URL.String())
+	assert.Equal(t, "http://hostD2.com", consumer.responses[3].Hosts[1].URL.String())
 
-	assert.Equal(t, "http://hostE1.com", consumer.responses[4].Left.URL.String())
-	assert.Equal(t, "http://hostE2.com", consumer.responses[4].Right.URL.String())
+	assert.Equal(t, "http://hostE1.com", consumer.responses[4].Hosts[0].URL.String())
+	assert.Equal(t, "http://hostE2.com", consumer.responses[4].Hosts[1].URL.String())
 
-	assert.Equal(t, "http://hostF1.com", consumer.responses[5].Left.URL.String())
-	assert.Equal(t, "http://hostF2.com", consumer.responses[5].Right.URL.String())
+	assert.Equal(t, "http://hostF1.com", consumer.responses[5].Hosts[0].URL.String())
+	assert.Equal(t, "http://hostF2.com", consumer.responses[5].Hosts[1].URL.String())
 }
 
 func TestRunWithCancel(t *testing.T) {
@@ -125,6 +131,23 @@ func TestRunWithCancel(t *testing.T) {
 	assert.Equal(t, 3, consumer.times)
 }
 
+func TestRunUntilStop(t *testing.T) {
+	stop, cancel := context.WithCancel(context.Background())
+	reader := new(readerStub)
+	producer := &producerStub{
+		toBeProcessed: 3,
+		cancel:        cancel,
+	}
+	consumer := new(consumerSpy)
+
+	p := New(reader, producer, consumer)
+
+	p.RunUntil(context.Background(), stop)
+	// Once stopped no more urls are read, but the pairs in flight are consumed.
+	assert.Equal(t, producer.processed, consumer.times)
+	assert.True(t, consumer.times > 3 && consumer.times < 6)
+}
+
 func sleepRandom(max int) {
 	r := rand.Intn(max)
 	time.Sleep(time.Duration(r) * time.Millisecond)
@@ -31,6 +31,17 @@ func (p *ProgressBar) IncrementError() {
 	p.errorPb.Add(1)
 }
 
+// Record increments the ok bar when both hosts are equal and the error bar otherwise.
+func (p *ProgressBar) Record(r Result) {
+	if r.Verdict == VerdictEqual {
+		p.IncrementOk()
+
+		return
+	}
+
+	p.IncrementError()
+}
+
 func (p *ProgressBar) Start() {
 	pool, err := pb.StartPool(p.okPb, p.errorPb)
 	if err != nil {
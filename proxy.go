@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// hopHeaders are the headers that must not be forwarded by a proxy. Accept-Encoding is also dropped
+// so every host responds with an uncompressed body that can be compared.
+var hopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+	"Content-Length",
+	"Accept-Encoding",
+}
+
+// Proxy is a reverse proxy that forwards every request to the baseline host, returning its response to the caller,
+// while mirroring it asynchronously to the rest of the hosts. The responses of all the hosts are sent to Pairs.
+type Proxy struct {
+	hosts   []string
+	headers map[string]string
+	options []HostOptions
+	primary Fetcher
+	fetcher Fetcher
+	log     *log.Logger
+	mirrors chan struct{}
+	pairs   chan HostsPair
+	wg      sync.WaitGroup
+}
+
+// NewProxy returns a Proxy that mirrors at most the given number of requests concurrently.
+// Requests received while all the mirrors are busy are only forwarded to the baseline host.
+// The baseline host is called with the primary fetcher, which must not retry since the caller may not expect its
+// request to be sent more than once and must get the response of the baseline host as is, while the mirrors are
+// called with fetcher.
+func NewProxy(hosts []string, headers map[string]string, options []HostOptions, primary, fetcher Fetcher, mirrors int,
+	log *log.Logger) *Proxy {
+	return &Proxy{
+		hosts:   hosts,
+		headers: headers,
+		options: options,
+		primary: primary,
+		fetcher: fetcher,
+		log:     log,
+		mirrors: make(chan struct{}, mirrors),
+		pairs:   make(chan HostsPair, mirrors),
+	}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	relURL := r.URL.RequestURI()
+	req := Request{
+		Method:  r.Method,
+		Headers: p.requestHeaders(r.Header),
+	}
+
+	if len(body) > 0 {
+		req.Body = body
+	}
+
+	primary := p.fetch(r.Context(), p.primary, 0, relURL, req)
+	if primary.Error != nil {
+		http.Error(w, primary.Error.Error(), http.StatusBadGateway)
+	} else {
+		copyHeaders(w.Header(), primary.Header)
+		w.WriteHeader(primary.StatusCode)
+		_, _ = w.Write(primary.Body)
+	}
+
+	select {
+	case p.mirrors <- struct{}{}:
+	default:
+		p.log.Warnf("dropping mirror: url %s: too many requests in flight", relURL)
+
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() { <-p.mirrors }()
+
+		p.pairs <- p.mirror(relURL, req, primary)
+	}()
+}
+
+// Pairs returns the stream of responses of every mirrored request.
+func (p *Proxy) Pairs() <-chan HostsPair {
+	return p.pairs
+}
+
+// Close waits for the mirrors in flight and closes the stream of pairs.
+// It must be called once the server stopped handling requests.
+func (p *Proxy) Close() {
+	p.wg.Wait()
+	close(p.pairs)
+}
+
+func (p *Proxy) mirror(relURL string, req Request, primary Host) HostsPair {
+	pair := HostsPair{
+		RelURL:  relURL,
+		Method:  req.Method,
+		Payload: req.Body,
+		Hosts:   make([]Host, len(p.hosts)),
+	}
+	pair.Hosts[0] = primary
+
+	var wg sync.WaitGroup
+	for i := 1; i < len(p.hosts); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Mirrors outlive the request, so they are not cancelled when the caller disconnects.
+			pair.Hosts[i] = p.fetch(context.Background(), p.fetcher, i, relURL, req)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, h := range pair.Hosts {
+		if h.Error != nil {
+			pair.Errors = append(pair.Errors, h.Error)
+		}
+	}
+
+	return pair
+}
+
+func (p *Proxy) fetch(ctx context.Context, fetcher Fetcher, host int, relURL string, req Request) Host {
+	u := URL{}
+	u.URL, u.Error = joinPath(p.hosts[host], relURL)
+
+	return fetchHost(ctx, hostFetcherFor(fetcher, p.options, host), u, req)
+}
+
+// requestHeaders returns the headers to be forwarded overridden by the ones configured for the proxy.
+func (p *Proxy) requestHeaders(h http.Header) map[string]string {
+	h = h.Clone()
+	for _, k := range hopHeaders {
+		h.Del(k)
+	}
+
+	headers := make(map[string]string, len(h)+len(p.headers))
+	for k, v := range h {
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	for k, v := range p.headers {
+		headers[k] = v
+	}
+
+	return headers
+}
+
+// copyHeaders adds the response headers of the baseline host to the response of the proxy, except for the hop ones.
+func copyHeaders(dst, src http.Header) {
+	for k, vs := range src {
+		for _, v := range vs {
+			dst.Add(k, v)
+		}
+	}
+
+	for _, k := range hopHeaders {
+		dst.Del(k)
+	}
+}
+
+func newProxyCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "proxy",
+		Usage: "listens as a reverse proxy forwarding every request to the first host and mirroring it to the rest of them to be compared",
+		Flags: append([]cli.Flag{
+			configFlag(),
+			&cli.StringFlag{
+				Name:  "listen",
+				Value: ":8080",
+				Usage: "address on which the proxy listens",
+			},
+			&cli.StringSliceFlag{
+				Name:  "host",
+				Usage: "targeted hosts. At least 2 must be specified, being the first one the one that responds to the caller. eg: --host 'http://host1.com --host 'http://host2.com'",
+			},
+			&cli.StringSliceFlag{
+				Name:    "header",
+				Aliases: []string{"H"},
+				Usage:   "headers to be added to every forwarded request",
+			},
+			&cli.IntFlag{
+				Name:    "workers",
+				Aliases: []string{"w"},
+				Value:   10,
+				Usage:   "maximum number of requests mirrored concurrently. Requests received while all the workers are busy are not compared",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Value: DefaultTimeout,
+				Usage: "request timeout",
+			},
+		}, append(append(hostFlags(), oauth2Flags()...), comparisonFlags()...)...),
+		Action: proxyAction,
+	}
+}
+
+func proxyAction(c *cli.Context) error {
+	if err := applyConfig(c); err != nil {
+		return err
+	}
+
+	hosts := c.StringSlice("host")
+	if len(hosts) < 2 {
+		log.Fatal("invalid number of hosts provided")
+	}
+
+	headers := parseHeaders(c.StringSlice("header"))
+	excludes := parseExclusionRules(c.StringSlice("exclude"), c.String("exclude-file"))
+	matchers := parseMatcherRules(c.StringSlice("match"))
+	proxy := NewProxy(hosts, headers, parseHostOptions(c, len(hosts)), parseHTTPClient(c, NoRetries()), parseHTTPClient(c),
+		c.Int("workers"), log.StandardLogger())
+
+	summary := NewSummary(hosts)
+	latency := NewLatencyReport(hosts, parseSlowerThreshold(c))
+	recorders := []Recorder{summary, latency}
+
+	outputs, err := parseOutputs(c, hosts, os.Create)
+	if err != nil {
+		return err
+	}
+	defer outputs.Close()
+	recorders = append(recorders, outputs.recorders()...)
+
+	consumer := NewConsumer(c.Bool("status-code-only"), log.StandardLogger(), excludes, matchers, parseHeaderRules(c),
+		parseComparator(c), recorders...)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for pair := range proxy.Pairs() {
+			consumer.Consume(pair)
+		}
+	}()
+
+	server := &http.Server{Addr: c.String("listen"), Handler: proxy}
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		<-signals
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.Duration("timeout"))
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}()
+
+	log.Printf("listening on %s", server.Addr)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+
+	proxy.Close()
+	<-done
+
+	summary.Print(os.Stdout)
+	latency.Print(os.Stdout)
+
+	return outputs.write()
+}
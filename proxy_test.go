@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxy(t *testing.T) {
+	primary := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, `{"q":"visa"}`, string(b))
+			assert.Equal(t, "abc", r.Header.Get("X-Auth-Token"))
+			w.Header().Set("Cache-Control", "max-age=60")
+			_, _ = w.Write([]byte(`{"name":"visa"}`))
+		}),
+	)
+	defer primary.Close()
+
+	candidate := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "/v1/search?site=MLA", r.URL.RequestURI())
+			assert.Equal(t, `{"q":"visa"}`, string(b))
+			_, _ = w.Write([]byte(`{"name":"master"}`))
+		}),
+	)
+	defer candidate.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	proxy := NewProxy([]string{primary.URL, candidate.URL}, map[string]string{"X-Auth-Token": "abc"}, nil, NewHTTPClient(NoRetries()), NewHTTPClient(), 1, logger)
+	server := httptest.NewServer(proxy)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/v1/search?site=MLA", "application/json", strings.NewReader(`{"q":"visa"}`))
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "max-age=60", resp.Header.Get("Cache-Control"))
+	assert.Equal(t, `{"name":"visa"}`, string(body))
+
+	select {
+	case pair := <-proxy.Pairs():
+		assert.Equal(t, "/v1/search?site=MLA", pair.RelURL)
+		assert.Len(t, pair.Hosts, 2)
+		assert.Equal(t, `{"name":"visa"}`, string(pair.Hosts[0].Body))
+		assert.Equal(t, `{"name":"master"}`, string(pair.Hosts[1].Body))
+	case <-time.After(5 * time.Second):
+		t.Fatal("mirrored request was not received")
+	}
+}
+
+func TestProxy_PrimaryIsNotRetried(t *testing.T) {
+	var calls int32
+	primary := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"internal"}`))
+		}),
+	)
+	defer primary.Close()
+
+	candidate := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"name":"master"}`))
+		}),
+	)
+	defer candidate.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+	proxy := NewProxy([]string{primary.URL, candidate.URL}, nil, nil, NewHTTPClient(NoRetries()), NewHTTPClient(), 1, logger)
+	server := httptest.NewServer(proxy)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/v1/cards", "application/json", strings.NewReader(`{"number":"4509"}`))
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, `{"error":"internal"}`, string(body))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	select {
+	case pair := <-proxy.Pairs():
+		assert.Equal(t, http.StatusInternalServerError, pair.Hosts[0].StatusCode)
+		assert.Equal(t, http.StatusOK, pair.Hosts[1].StatusCode)
+	case <-time.After(5 * time.Second):
+		t.Fatal("mirrored request was not received")
+	}
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxReportedMismatches is the maximum number of mismatched urls whose bodies are included in an html report,
+// so the report of a run with plenty of them can still be opened by a browser.
+const maxReportedMismatches = 1000
+
+// HTMLReport is a Recorder that collects the tally of every verdict, overall, by endpoint group and by diff path,
+// along with the bodies of the mismatched urls, to write them as a self-contained html page.
+type HTMLReport struct {
+	mu        sync.Mutex
+	hosts     []string
+	summary   *Summary
+	endpoints map[string]map[Verdict]int
+	paths     map[string]int
+	// mismatches holds the first maxReportedMismatches results that are not equal, omitted counts the rest.
+	mismatches []reportMismatch
+	omitted    int
+}
+
+type reportMismatch struct {
+	RelURL      string
+	Method      string
+	Verdict     Verdict
+	Errors      []string
+	Comparisons []reportComparison
+}
+
+// reportComparison holds the bodies of two hosts rendered as html, with the values that differ highlighted.
+type reportComparison struct {
+	Left, Right             string
+	Verdict                 Verdict
+	LeftStatus, RightStatus int
+	Diffs                   []reportDiff
+	LeftBody, RightBody     template.HTML
+}
+
+type reportDiff struct {
+	Path, Type, Left, Right string
+}
+
+type reportCount struct {
+	Name       string
+	Count      int
+	Percentage float64
+}
+
+type reportEndpoint struct {
+	Endpoint string
+	Total    int
+	Counts   []int
+}
+
+// reportData is the data from which the html page is rendered.
+type reportData struct {
+	Hosts      []string
+	Total      int
+	Verdicts   []Verdict
+	Counts     []reportCount
+	Endpoints  []reportEndpoint
+	Paths      []reportCount
+	Mismatches []reportMismatch
+	Omitted    int
+}
+
+func NewHTMLReport(hosts []string) *HTMLReport {
+	return &HTMLReport{
+		hosts:     hosts,
+		summary:   NewSummary(hosts),
+		endpoints: make(map[string]map[Verdict]int),
+		paths:     make(map[string]int),
+	}
+}
+
+func (h *HTMLReport) Record(r Result) {
+	h.summary.Record(r)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	group := endpointGroup(r.RelURL)
+	if _, ok := h.endpoints[group]; !ok {
+		h.endpoints[group] = make(map[Verdict]int, len(verdicts))
+	}
+	h.endpoints[group][r.Verdict]++
+
+	// Every path is counted once per url, no matter how many elements of an array or hosts differ on it.
+	paths := make(map[string]bool)
+	for _, cmp := range r.Comparisons {
+		for _, d := range cmp.Diffs {
+			paths[normalizePath(d.Path)] = true
+		}
+
+		for _, d := range cmp.Headers {
+			paths["header "+d.Path] = true
+		}
+	}
+
+	for path := range paths {
+		h.paths[path]++
+	}
+
+	if r.Verdict == VerdictEqual {
+		return
+	}
+
+	if len(h.mismatches) == maxReportedMismatches {
+		h.omitted++
+
+		return
+	}
+
+	h.mismatches = append(h.mismatches, h.newMismatch(r))
+}
+
+func (h *HTMLReport) newMismatch(r Result) reportMismatch {
+	m := reportMismatch{RelURL: r.RelURL, Method: r.Method, Verdict: r.Verdict}
+	for _, err := range r.Errors {
+		m.Errors = append(m.Errors, err.Error())
+	}
+
+	for _, cmp := range r.Comparisons {
+		if cmp.Verdict == VerdictEqual || cmp.Left >= len(r.Hosts) || cmp.Right >= len(r.Hosts) {
+			continue
+		}
+
+		left, right := r.Hosts[cmp.Left], r.Hosts[cmp.Right]
+		rc := reportComparison{
+			Left:        h.hosts[cmp.Left],
+			Right:       h.hosts[cmp.Right],
+			Verdict:     cmp.Verdict,
+			LeftStatus:  left.StatusCode,
+			RightStatus: right.StatusCode,
+		}
+
+		for _, d := range cmp.Headers {
+			rc.Diffs = append(rc.Diffs, newReportDiff("header "+d.Path, d))
+		}
+
+		// Values missing on one of the sides are only highlighted on the other one.
+		leftPaths, rightPaths := make(map[string]bool), make(map[string]bool)
+		for _, d := range cmp.Diffs {
+			rc.Diffs = append(rc.Diffs, newReportDiff(d.Path, d))
+			if d.Type != MissingLeft {
+				leftPaths[d.Path] = true
+			}
+
+			if d.Type != MissingRight {
+				rightPaths[d.rightPath()] = true
+			}
+		}
+
+		rc.LeftBody = renderBody(left.Body, leftPaths)
+		rc.RightBody = renderBody(right.Body, rightPaths)
+		m.Comparisons = append(m.Comparisons, rc)
+	}
+
+	return m
+}
+
+// Write renders the report as a self-contained html page.
+func (h *HTMLReport) Write(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	data := reportData{
+		Hosts:      h.hosts,
+		Total:      h.summary.Total(),
+		Verdicts:   verdicts,
+		Mismatches: h.mismatches,
+		Omitted:    h.omitted,
+	}
+
+	for _, v := range verdicts {
+		count := h.summary.Count(v)
+		data.Counts = append(data.Counts, reportCount{Name: string(v), Count: count, Percentage: percentage(count, data.Total)})
+	}
+
+	for _, group := range sortedGroups(h.endpoints) {
+		e := reportEndpoint{Endpoint: group}
+		for _, v := range verdicts {
+			e.Counts = append(e.Counts, h.endpoints[group][v])
+			e.Total += h.endpoints[group][v]
+		}
+		data.Endpoints = append(data.Endpoints, e)
+	}
+
+	for path, count := range h.paths {
+		data.Paths = append(data.Paths, reportCount{Name: path, Count: count, Percentage: percentage(count, data.Total)})
+	}
+
+	// The paths that differ the most go first.
+	sort.Slice(data.Paths, func(i, j int) bool {
+		if data.Paths[i].Count != data.Paths[j].Count {
+			return data.Paths[i].Count > data.Paths[j].Count
+		}
+
+		return data.Paths[i].Name < data.Paths[j].Name
+	})
+
+	return reportTemplate.Execute(w, data)
+}
+
+// WriteFile renders the report as a self-contained html page in the given file.
+func (h *HTMLReport) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Write(f); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+func sortedGroups(m map[string]map[Verdict]int) []string {
+	groups := make([]string, 0, len(m))
+	for group := range m {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	return groups
+}
+
+func newReportDiff(path string, d Difference) reportDiff {
+	diff := reportDiff{Path: path, Type: d.Type.String()}
+	if d.Type != MissingLeft {
+		diff.Left = formatValue(d.Left)
+	}
+
+	if d.Type != MissingRight {
+		diff.Right = formatValue(d.Right)
+	}
+
+	return diff
+}
+
+// formatValue returns the json representation of a value without escaping html, which is escaped when rendered.
+func formatValue(v interface{}) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderBody returns a json body indented as html, with the values at the given paths highlighted.
+// Bodies that are not json are escaped as they are.
+func renderBody(body []byte, highlight map[string]bool) template.HTML {
+	j, err := Unmarshal(body)
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(string(body))) // #nosec G203
+	}
+
+	var b strings.Builder
+	renderValue(&b, "", "", j, highlight)
+
+	return template.HTML(b.String()) // #nosec G203
+}
+
+// renderValue writes the value at the given path, escaping every string written.
+func renderValue(b *strings.Builder, indent, path string, v interface{}, highlight map[string]bool) {
+	if highlight[path] {
+		b.WriteString(`<mark>`)
+		defer b.WriteString(`</mark>`)
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if len(t) == 0 {
+			b.WriteString("{}")
+
+			return
+		}
+
+		b.WriteString("{\n")
+		keys := sortedKeys(t)
+		for i, k := range keys {
+			b.WriteString(indent + "  ")
+			key := joinKey(path, k)
+			if highlight[key] {
+				// The key is highlighted along with its value.
+				b.WriteString(`<mark>`)
+				b.WriteString(template.HTMLEscapeString(formatValue(k)) + ": ")
+				renderValue(b, indent+"  ", key, t[k], nil)
+				b.WriteString(`</mark>`)
+			} else {
+				b.WriteString(template.HTMLEscapeString(formatValue(k)) + ": ")
+				renderValue(b, indent+"  ", key, t[k], highlight)
+			}
+
+			if i < len(keys)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "}")
+	case []interface{}:
+		if len(t) == 0 {
+			b.WriteString("[]")
+
+			return
+		}
+
+		b.WriteString("[\n")
+		for i, e := range t {
+			b.WriteString(indent + "  ")
+			renderValue(b, indent+"  ", joinKey(path, strconv.Itoa(i)), e, highlight)
+			if i < len(t)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "]")
+	default:
+		b.WriteString(template.HTMLEscapeString(formatValue(t)))
+	}
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percentage": func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) + "%" },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>gomparator report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292e; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #d1d5da; padding: 4px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+td.count { text-align: right; }
+details { border: 1px solid #d1d5da; border-radius: 4px; margin-bottom: 8px; padding: 6px 10px; }
+summary { cursor: pointer; font-family: monospace; }
+.verdict { display: inline-block; min-width: 7em; font-weight: bold; }
+.side-by-side { display: flex; gap: 1em; }
+.side-by-side > div { flex: 1; min-width: 0; }
+pre { background: #f6f8fa; padding: 8px; overflow-x: auto; font-size: 12px; }
+mark { background: #ffdce0; }
+.error { color: #cb2431; }
+</style>
+</head>
+<body>
+<h1>gomparator report</h1>
+<p>Hosts: {{range $i, $h := .Hosts}}{{if $i}}, {{end}}<code>{{$h}}</code>{{end}}. The first one is the baseline.</p>
+
+<h2>Summary</h2>
+<table>
+<tr><th>total</th><td class="count">{{.Total}}</td><td></td></tr>
+{{range .Counts}}<tr><th>{{.Name}}</th><td class="count">{{.Count}}</td><td class="count">{{percentage .Percentage}}</td></tr>
+{{end}}</table>
+
+<h2>By endpoint</h2>
+<table>
+<tr><th>endpoint</th><th>total</th>{{range .Verdicts}}<th>{{.}}</th>{{end}}</tr>
+{{range .Endpoints}}<tr><td><code>{{.Endpoint}}</code></td><td class="count">{{.Total}}</td>{{range .Counts}}<td class="count">{{.}}</td>{{end}}</tr>
+{{end}}</table>
+
+<h2>By diff path</h2>
+{{if .Paths}}<table>
+<tr><th>path</th><th>urls</th><th>of total</th></tr>
+{{range .Paths}}<tr><td><code>{{.Name}}</code></td><td class="count">{{.Count}}</td><td class="count">{{percentage .Percentage}}</td></tr>
+{{end}}</table>{{else}}<p>No differences found.</p>{{end}}
+
+<h2>Mismatches</h2>
+{{range .Mismatches}}<details>
+<summary><span class="verdict">{{.Verdict}}</span> {{if .Method}}{{.Method}} {{end}}{{.RelURL}}</summary>
+{{range .Errors}}<p class="error">{{.}}</p>
+{{end}}{{range .Comparisons}}<h4><code>{{.Left}}</code> ({{.LeftStatus}}) - <code>{{.Right}}</code> ({{.RightStatus}}): {{.Verdict}}</h4>
+{{if .Diffs}}<table>
+<tr><th>path</th><th>type</th><th>left</th><th>right</th></tr>
+{{range .Diffs}}<tr><td><code>{{.Path}}</code></td><td>{{.Type}}</td><td><code>{{.Left}}</code></td><td><code>{{.Right}}</code></td></tr>
+{{end}}</table>{{end}}
+<div class="side-by-side">
+<div><pre>{{.LeftBody}}</pre></div>
+<div><pre>{{.RightBody}}</pre></div>
+</div>
+{{end}}</details>
+{{else}}<p>Every response was equal.</p>
+{{end}}{{if .Omitted}}<p>{{.Omitted}} more mismatches were not included.</p>{{end}}
+</body>
+</html>
+`))
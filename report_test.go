@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLReport(t *testing.T) {
+	hosts := []string{"http://host1.com", "http://host2.com"}
+	r := NewHTMLReport(hosts)
+
+	r.Record(Result{RelURL: "/v1/cards/1", Method: http.MethodGet, Verdict: VerdictEqual})
+	r.Record(Result{
+		RelURL:  "/v1/cards/2",
+		Method:  http.MethodGet,
+		Verdict: VerdictBodyDiff,
+		Hosts: []Host{
+			{StatusCode: 200, Body: []byte(`{"name":"<b>visa</b>","id":2}`)},
+			{StatusCode: 200, Body: []byte(`{"name":"master","id":2,"extra":true}`)},
+		},
+		Comparisons: []Comparison{{Left: 0, Right: 1, Verdict: VerdictBodyDiff, Diffs: []Difference{
+			{Type: ValueMismatch, Path: "name", Left: "<b>visa</b>", Right: "master"},
+			{Type: MissingLeft, Path: "extra", Right: true},
+		}}},
+	})
+	r.Record(Result{RelURL: "/v1/cards/3", Method: http.MethodGet, Verdict: VerdictError, Errors: []error{errors.New("connection refused")}})
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, r.Write(out))
+	html := out.String()
+
+	assert.Contains(t, html, `<tr><th>body-diff</th><td class="count">1</td><td class="count">33.33%</td></tr>`)
+	assert.Contains(t, html, `<tr><td><code>/v1/cards/{id}</code></td><td class="count">3</td><td class="count">1</td><td class="count">0</td><td class="count">1</td><td class="count">0</td><td class="count">1</td></tr>`)
+	assert.Contains(t, html, `<tr><td><code>extra</code></td><td class="count">1</td><td class="count">33.33%</td></tr>`)
+	assert.Contains(t, html, `<summary><span class="verdict">body-diff</span> GET /v1/cards/2</summary>`)
+	assert.Contains(t, html, `<p class="error">connection refused</p>`)
+	// Bodies are escaped, with the values that differ highlighted on each side.
+	assert.Contains(t, html, `<mark>&#34;name&#34;: &#34;&lt;b&gt;visa&lt;/b&gt;&#34;</mark>`)
+	assert.Contains(t, html, `<mark>&#34;extra&#34;: true</mark>`)
+	assert.NotContains(t, html, `<b>visa</b>`)
+}
+
+func TestHTMLReport_PairedElements(t *testing.T) {
+	r := NewHTMLReport([]string{"http://host1.com", "http://host2.com"})
+
+	// The element with id 2 is first on the left and second on the right.
+	r.Record(Result{
+		RelURL:  "/v1/cards",
+		Verdict: VerdictBodyDiff,
+		Hosts: []Host{
+			{StatusCode: 200, Body: []byte(`[{"id":2,"name":"visa"},{"id":1,"name":"amex"}]`)},
+			{StatusCode: 200, Body: []byte(`[{"id":1,"name":"amex"},{"id":2,"name":"master"}]`)},
+		},
+		Comparisons: []Comparison{{Left: 0, Right: 1, Verdict: VerdictBodyDiff, Diffs: []Difference{
+			{Type: ValueMismatch, Path: "0.name", RightPath: "1.name", Left: "visa", Right: "master"},
+		}}},
+	})
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, r.Write(out))
+	html := out.String()
+
+	assert.Contains(t, html, `<mark>&#34;name&#34;: &#34;visa&#34;</mark>`)
+	assert.Contains(t, html, `<mark>&#34;name&#34;: &#34;master&#34;</mark>`)
+	assert.NotContains(t, html, `<mark>&#34;name&#34;: &#34;amex&#34;</mark>`)
+}
+
+func TestRenderBody(t *testing.T) {
+	body := renderBody([]byte(`{"items":[{"id":1},{"id":2}],"empty":{}}`), map[string]bool{"items.1.id": true})
+	assert.Equal(t, `{
+  &#34;empty&#34;: {},
+  &#34;items&#34;: [
+    {
+      &#34;id&#34;: 1
+    },
+    {
+      <mark>&#34;id&#34;: 2</mark>
+    }
+  ]
+}`, string(body))
+
+	assert.Equal(t, "not &lt;json&gt;", string(renderBody([]byte("not <json>"), nil)))
+}
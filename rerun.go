@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// ParseVerdict returns the Verdict of the given name. eg: body-diff
+func ParseVerdict(s string) (Verdict, error) {
+	names := make([]string, len(verdicts))
+	for i, v := range verdicts {
+		if string(v) == s {
+			return v, nil
+		}
+		names[i] = string(v)
+	}
+
+	return "", fmt.Errorf("invalid verdict %q: expected one of %s", s, strings.Join(names, ", "))
+}
+
+// Rerun returns the requests of the results read from r with any of the given verdicts, so they can be written to a
+// targets file in the given format with writeRequests and compared again.
+func Rerun(r io.Reader, format string, only []Verdict) ([]jsonlRequest, error) {
+	selected := make(map[Verdict]bool, len(only))
+	for _, v := range only {
+		selected[v] = true
+	}
+
+	var requests []jsonlRequest
+	dec := json.NewDecoder(r)
+	for {
+		var record resultRecord
+		err := dec.Decode(&record)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid result: %v", err)
+		}
+
+		if !selected[record.Verdict] {
+			continue
+		}
+
+		req := jsonlRequest{Method: http.MethodGet, Path: record.RelURL}
+		if record.Request != nil {
+			req = *record.Request
+			if format != FormatJSONL {
+				return nil, fmt.Errorf("%s %s cannot be written as text since it has headers or a body, use the jsonl format",
+					req.Method, req.Path)
+			}
+		}
+
+		requests = append(requests, req)
+	}
+
+	return requests, nil
+}
+
+// writeRequests writes the requests to w in the given format of a targets file.
+func writeRequests(w io.Writer, requests []jsonlRequest, format string) error {
+	enc := json.NewEncoder(w)
+	for _, req := range requests {
+		var err error
+		if format == FormatJSONL {
+			err = enc.Encode(req)
+		} else {
+			_, err = fmt.Fprintln(w, req.Path)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newRerunCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "rerun",
+		Usage: "writes the requests of a previous run with the given verdicts to a new targets file, so only those are compared again",
+		Flags: []cli.Flag{
+			configFlag(),
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "specifies the results file written with --output by a previous run",
+			},
+			&cli.StringSliceFlag{
+				Name:  "only",
+				Value: cli.NewStringSlice(string(VerdictStatusDiff), string(VerdictBodyDiff), string(VerdictHeaderDiff), string(VerdictError)),
+				Usage: "verdicts of the requests to be compared again. eg: --only body-diff --only error",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "specifies the file in which to write the requests. If not given, they are written to the standard output",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: FormatText,
+				Usage: "format of the file written. Either text, with one rel path per line, or jsonl, required for requests with a method other than GET, headers or a body",
+			},
+		},
+		Action: rerunAction,
+	}
+}
+
+func rerunAction(c *cli.Context) error {
+	if err := applyConfig(c); err != nil {
+		return err
+	}
+
+	from := c.String("from")
+	if from == "" {
+		log.Fatal("from must be specified")
+	}
+
+	format := c.String("format")
+	if format != FormatText && format != FormatJSONL {
+		log.Fatalf("invalid format provided: %s", format)
+	}
+
+	var only []Verdict
+	for _, s := range c.StringSlice("only") {
+		v, err := ParseVerdict(s)
+		if err != nil {
+			log.Fatal(err)
+		}
+		only = append(only, v)
+	}
+
+	in, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// The file is created once every request is read, so it is not if any of them cannot be.
+	requests, err := Rerun(in, format, only)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if to := c.String("to"); to != "" {
+		if out, err = os.Create(to); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	if err := writeRequests(out, requests, format); err != nil {
+		return err
+	}
+
+	// The count goes to the standard error so the requests can be piped.
+	fmt.Fprintf(os.Stderr, "%d requests to compare again\n", len(requests))
+
+	return nil
+}
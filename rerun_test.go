@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRerun(t *testing.T) {
+	results := new(bytes.Buffer)
+	w := NewResultWriter(results)
+	w.Record(Result{RelURL: "/v1/cards/1", Method: http.MethodGet, Verdict: VerdictEqual})
+	w.Record(Result{RelURL: "/v1/cards/2", Method: http.MethodGet, Verdict: VerdictBodyDiff})
+	w.Record(Result{RelURL: "/v1/cards/3", Method: http.MethodGet, Verdict: VerdictError})
+	w.Record(Result{RelURL: "/v1/payments", Method: http.MethodPost, Payload: []byte(`{"amount":10}`), Verdict: VerdictBodyDiff})
+	assert.NoError(t, w.Err())
+
+	out := new(bytes.Buffer)
+	requests, err := Rerun(bytes.NewReader(results.Bytes()), FormatJSONL, []Verdict{VerdictBodyDiff})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+	assert.NoError(t, writeRequests(out, requests, FormatJSONL))
+	assert.Equal(t, `{"method":"GET","path":"/v1/cards/2"}
+{"method":"POST","path":"/v1/payments","body":{"amount":10}}
+`, out.String())
+
+	requests, err = Rerun(bytes.NewReader(results.Bytes()), FormatText, []Verdict{VerdictBodyDiff, VerdictError})
+	assert.EqualError(t, err, "POST /v1/payments cannot be written as text since it has headers or a body, use the jsonl format")
+	assert.Empty(t, requests)
+
+	out.Reset()
+	requests, err = Rerun(bytes.NewReader(results.Bytes()), FormatText, []Verdict{VerdictError, VerdictEqual})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+	assert.NoError(t, writeRequests(out, requests, FormatText))
+	assert.Equal(t, "/v1/cards/1\n/v1/cards/3\n", out.String())
+}
+
+func TestRerunInvalidResults(t *testing.T) {
+	_, err := Rerun(strings.NewReader(`{"rel_url":`), FormatText, []Verdict{VerdictError})
+	assert.Error(t, err)
+}
+
+func TestParseVerdict(t *testing.T) {
+	v, err := ParseVerdict("body-diff")
+	assert.NoError(t, err)
+	assert.Equal(t, VerdictBodyDiff, v)
+
+	_, err = ParseVerdict("mismatch")
+	assert.EqualError(t, err, `invalid verdict "mismatch": expected one of equal, status-diff, body-diff, header-diff, error`)
+}
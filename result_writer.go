@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ResultWriter is a Recorder that writes every Result as a json object per line.
+type ResultWriter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+type resultRecord struct {
+	RelURL string `json:"rel_url"`
+	// Request is only written for requests other than a plain GET, which are fully described by their rel url.
+	Request     *jsonlRequest      `json:"request,omitempty"`
+	Verdict     Verdict            `json:"verdict"`
+	Hosts       []hostRecord       `json:"hosts"`
+	Comparisons []comparisonRecord `json:"comparisons,omitempty"`
+	Errors      []string           `json:"errors,omitempty"`
+	Noise       []string           `json:"noise,omitempty"`
+}
+
+// comparisonRecord identifies the compared hosts by their index in the hosts list.
+type comparisonRecord struct {
+	Left    int          `json:"left"`
+	Right   int          `json:"right"`
+	Verdict Verdict      `json:"verdict"`
+	Diffs   []diffRecord `json:"diffs,omitempty"`
+	Headers []diffRecord `json:"headers,omitempty"`
+}
+
+type hostRecord struct {
+	URL        string  `json:"url,omitempty"`
+	StatusCode int     `json:"status_code,omitempty"`
+	ElapsedMs  float64 `json:"elapsed_ms"`
+	TTFBMs     float64 `json:"ttfb_ms,omitempty"`
+}
+
+type diffRecord struct {
+	Path string `json:"path"`
+	// RightPath is only written when the value on the right is at another path, eg: inside paired array elements.
+	RightPath string `json:"right_path,omitempty"`
+	Type      string `json:"type"`
+	// Left and Right are always written, since a null value is a value that differs as well.
+	Left  interface{} `json:"left"`
+	Right interface{} `json:"right"`
+}
+
+func NewResultWriter(w io.Writer) *ResultWriter {
+	return &ResultWriter{enc: json.NewEncoder(w)}
+}
+
+func (w *ResultWriter) Record(r Result) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return
+	}
+
+	w.err = w.enc.Encode(newResultRecord(r))
+}
+
+// Err returns the first error found while writing results, if any.
+func (w *ResultWriter) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err
+}
+
+func newResultRecord(r Result) resultRecord {
+	record := resultRecord{
+		RelURL:  r.RelURL,
+		Verdict: r.Verdict,
+		Hosts:   make([]hostRecord, len(r.Hosts)),
+		Noise:   r.Noise,
+	}
+
+	if (r.Method != "" && r.Method != http.MethodGet) || len(r.Headers) > 0 || len(r.Payload) > 0 {
+		req := newJSONLRequest(r.Method, r.RelURL, r.Headers, r.Payload)
+		record.Request = &req
+	}
+
+	for i, h := range r.Hosts {
+		record.Hosts[i] = newHostRecord(h)
+	}
+
+	for _, cmp := range r.Comparisons {
+		cr := comparisonRecord{
+			Left:    cmp.Left,
+			Right:   cmp.Right,
+			Verdict: cmp.Verdict,
+		}
+
+		cr.Diffs = newDiffRecords(cmp.Diffs)
+		cr.Headers = newDiffRecords(cmp.Headers)
+		record.Comparisons = append(record.Comparisons, cr)
+	}
+
+	for _, err := range r.Errors {
+		record.Errors = append(record.Errors, err.Error())
+	}
+
+	return record
+}
+
+func newDiffRecords(diffs []Difference) []diffRecord {
+	var records []diffRecord
+	for _, d := range diffs {
+		records = append(records, diffRecord{
+			Path:      d.Path,
+			RightPath: d.RightPath,
+			Type:      d.Type.String(),
+			Left:      d.Left,
+			Right:     d.Right,
+		})
+	}
+
+	return records
+}
+
+func newHostRecord(h Host) hostRecord {
+	record := hostRecord{
+		StatusCode: h.StatusCode,
+		ElapsedMs:  float64(h.Elapsed) / float64(time.Millisecond),
+		TTFBMs:     float64(h.TTFB) / float64(time.Millisecond),
+	}
+
+	if h.URL != nil {
+		record.URL = h.URL.String()
+	}
+
+	return record
+}
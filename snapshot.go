@@ -0,0 +1,428 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" // #nosec G505 -- used as a content key, not for security
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/ratelimit"
+)
+
+const (
+	// snapshotIndex is the file, in jsonl format, holding every request recorded in a snapshot.
+	snapshotIndex = "requests.jsonl"
+	// snapshotResponses is the directory holding the response of every request, keyed by snapshotKey.
+	snapshotResponses = "responses"
+)
+
+// snapshotResponse is the representation of a recorded response.
+// Json bodies are stored as is so they can be read, while any other body is stored base64 encoded.
+type snapshotResponse struct {
+	StatusCode int             `json:"status_code"`
+	Headers    http.Header     `json:"headers,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	BodyBase64 string          `json:"body_base64,omitempty"`
+}
+
+// SnapshotWriter is a Consumer that stores the response of the first host of every HostsPair in a directory.
+type SnapshotWriter struct {
+	mu       sync.Mutex
+	dir      string
+	index    *os.File
+	log      *log.Logger
+	recorded int
+	failed   int
+}
+
+// CreateSnapshot creates the snapshot directory, truncating its index if it already exists.
+func CreateSnapshot(dir string, log *log.Logger) (*SnapshotWriter, error) {
+	if err := os.MkdirAll(filepath.Join(dir, snapshotResponses), 0750); err != nil {
+		return nil, err
+	}
+
+	index, err := os.Create(filepath.Join(dir, snapshotIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotWriter{
+		dir:   dir,
+		index: index,
+		log:   log,
+	}, nil
+}
+
+func (s *SnapshotWriter) Consume(val HostsPair) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.save(val); err != nil {
+		s.failed++
+		s.log.Errorf("could not record response: url %s: %v", val.RelURL, err)
+
+		return
+	}
+
+	s.recorded++
+}
+
+func (s *SnapshotWriter) save(val HostsPair) error {
+	if val.HasErrors() {
+		return val.Errors[0]
+	}
+
+	host := val.Hosts[0]
+	response := snapshotResponse{
+		StatusCode: host.StatusCode,
+		Headers:    host.Header,
+	}
+
+	if json.Valid(host.Body) {
+		response.Body = host.Body
+	} else if len(host.Body) > 0 {
+		response.BodyBase64 = base64.StdEncoding.EncodeToString(host.Body)
+	}
+
+	b, err := marshalUnescaped(response)
+	if err != nil {
+		return err
+	}
+
+	// The index compacts json bodies, so the response is keyed by the body read back from it, which is the one
+	// sent when the snapshot is compared.
+	line, err := marshalUnescaped(newJSONLRequest(val.Method, val.RelURL, val.Headers, val.Payload))
+	if err != nil {
+		return err
+	}
+
+	req, err := parseJSONL(string(line))
+	if err != nil {
+		return err
+	}
+
+	key := snapshotKey(req.Method, host.URL.RequestURI(), req.Body)
+	if err := ioutil.WriteFile(filepath.Join(s.dir, snapshotResponses, key+".json"), b, 0600); err != nil {
+		return err
+	}
+
+	_, err = s.index.Write(line)
+
+	return err
+}
+
+// marshalUnescaped returns the json encoding of v followed by a new line, without escaping html characters
+// such as < so the bodies of requests and responses keep them as received.
+func marshalUnescaped(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// Counts returns the number of responses recorded and the ones that failed.
+func (s *SnapshotWriter) Counts() (recorded, failed int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.recorded, s.failed
+}
+
+func (s *SnapshotWriter) Close() error {
+	return s.index.Close()
+}
+
+// SnapshotFetcher is a Fetcher that responds with the responses recorded in a snapshot directory.
+// Only the path and query of the requested url are taken into account, so any host can be used.
+type SnapshotFetcher struct {
+	dir string
+}
+
+func NewSnapshotFetcher(dir string) *SnapshotFetcher {
+	return &SnapshotFetcher{dir: dir}
+}
+
+func (s *SnapshotFetcher) Fetch(ctx context.Context, req Request) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(s.dir, snapshotResponses, snapshotKey(method, u.RequestURI(), req.Body)+".json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s %s not found in snapshot %s", method, u.RequestURI(), s.dir)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var response snapshotResponse
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	res := &Response{
+		StatusCode: response.StatusCode,
+		Header:     response.Headers,
+		Body:       response.Body,
+	}
+
+	if response.BodyBase64 != "" {
+		if res.Body, err = base64.StdEncoding.DecodeString(response.BodyBase64); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// OpenSnapshotIndex opens the file listing the requests recorded in a snapshot, in jsonl format.
+func OpenSnapshotIndex(dir string) (*os.File, error) {
+	return os.Open(filepath.Join(dir, snapshotIndex))
+}
+
+// snapshotKey identifies a request by its method, its normalized rel url and its body.
+func snapshotKey(method, requestURI string, body []byte) string {
+	h := sha1.New() // #nosec G401
+	_, _ = fmt.Fprintf(h, "%s %s\n", method, requestURI)
+	_, _ = h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// routingFetcher delegates every request to the Fetcher registered for its host, falling back to the default one.
+type routingFetcher struct {
+	fetchers map[string]Fetcher
+	fallback Fetcher
+}
+
+func (r *routingFetcher) Fetch(ctx context.Context, req Request) (*Response, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if f, ok := r.fetchers[u.Host]; ok {
+		return f.Fetch(ctx, req)
+	}
+
+	return r.fallback.Fetch(ctx, req)
+}
+
+const (
+	leftSnapshotHost  = "left.snapshot"
+	rightSnapshotHost = "right.snapshot"
+)
+
+func newRecordCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "record",
+		Usage: "records the responses of a single host in a snapshot directory to be compared later",
+		Flags: append([]cli.Flag{
+			configFlag(),
+			&cli.StringFlag{
+				Name:  "path",
+				Usage: "specifies the file from which to read targets",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: FormatText,
+				Usage: "format of the file specified in path. Either text or jsonl",
+			},
+			&cli.StringFlag{
+				Name:  "host",
+				Usage: "targeted host. eg: --host 'http://host1.com'",
+			},
+			&cli.StringFlag{
+				Name:  "snapshot",
+				Usage: "directory in which to store the responses",
+			},
+			&cli.StringSliceFlag{
+				Name:    "header",
+				Aliases: []string{"H"},
+				Usage:   "headers to be used in the http call",
+			},
+			&cli.IntFlag{
+				Name:    "ratelimit",
+				Aliases: []string{"r"},
+				Value:   5,
+				Usage:   "operation rate limit per second",
+			},
+			&cli.IntFlag{
+				Name:    "workers",
+				Aliases: []string{"w"},
+				Value:   1,
+				Usage:   "number of workers running concurrently",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Value: DefaultTimeout,
+				Usage: "request timeout",
+			},
+		}, oauth2Flags()...),
+		Action: recordAction,
+	}
+}
+
+func recordAction(c *cli.Context) error {
+	if err := applyConfig(c); err != nil {
+		return err
+	}
+
+	host := c.String("host")
+	dir := c.String("snapshot")
+	if host == "" || dir == "" {
+		log.Fatal("host and snapshot must be specified")
+	}
+
+	format := c.String("format")
+	if format != FormatText && format != FormatJSONL {
+		log.Fatalf("invalid format provided: %s", format)
+	}
+
+	file, err := os.Open(c.String("path"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	snapshot, err := CreateSnapshot(dir, log.StandardLogger())
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+
+	reader := NewReader(file, []string{host}, format)
+	producer := NewProducer(c.Int("workers"), parseHeaders(c.StringSlice("header")), nil,
+		ratelimit.New(c.Int("ratelimit")), parseHTTPClient(c), false)
+	New(reader, producer, snapshot).Run(context.Background())
+
+	recorded, failed := snapshot.Counts()
+	fmt.Printf("recorded %d responses in %s, %d failed\n", recorded, dir, failed)
+
+	return nil
+}
+
+func newCompareSnapshotsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "compare-snapshots",
+		Usage: "compares the responses recorded in a snapshot against another snapshot or a live host",
+		Flags: append([]cli.Flag{
+			configFlag(),
+			&cli.StringFlag{
+				Name:  "left",
+				Usage: "snapshot directory used as baseline",
+			},
+			&cli.StringFlag{
+				Name:  "right",
+				Usage: "snapshot directory or live host to compare against. eg: --right 'http://host1.com'",
+			},
+			&cli.StringSliceFlag{
+				Name:    "header",
+				Aliases: []string{"H"},
+				Usage:   "headers to be used in the http call to a live host",
+			},
+			&cli.IntFlag{
+				Name:    "ratelimit",
+				Aliases: []string{"r"},
+				Value:   5,
+				Usage:   "operation rate limit per second when comparing against a live host",
+			},
+			&cli.IntFlag{
+				Name:    "workers",
+				Aliases: []string{"w"},
+				Value:   1,
+				Usage:   "number of workers running concurrently",
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Value: DefaultTimeout,
+				Usage: "request timeout",
+			},
+		}, append(oauth2Flags(), comparisonFlags()...)...),
+		Action: compareSnapshotsAction,
+	}
+}
+
+func compareSnapshotsAction(c *cli.Context) error {
+	if err := applyConfig(c); err != nil {
+		return err
+	}
+
+	left, right := c.String("left"), c.String("right")
+	if left == "" || right == "" {
+		log.Fatal("left and right must be specified")
+	}
+
+	fetcher := &routingFetcher{
+		fetchers: map[string]Fetcher{leftSnapshotHost: NewSnapshotFetcher(left)},
+		fallback: parseHTTPClient(c),
+	}
+	hosts := []string{"http://" + leftSnapshotHost, right}
+	limiter := ratelimit.New(c.Int("ratelimit"))
+
+	if !isLiveHost(right) {
+		fetcher.fetchers[rightSnapshotHost] = NewSnapshotFetcher(right)
+		hosts[1] = "http://" + rightSnapshotHost
+		limiter = ratelimit.NewUnlimited()
+	}
+
+	index, err := OpenSnapshotIndex(left)
+	if err != nil {
+		return err
+	}
+	defer index.Close()
+
+	summary := NewSummary([]string{left, right})
+	recorders := []Recorder{summary}
+
+	outputs, err := parseOutputs(c, []string{left, right}, os.Create)
+	if err != nil {
+		return err
+	}
+	defer outputs.Close()
+	recorders = append(recorders, outputs.recorders()...)
+
+	excludes := parseExclusionRules(c.StringSlice("exclude"), c.String("exclude-file"))
+	matchers := parseMatcherRules(c.StringSlice("match"))
+	reader := NewReader(index, hosts, FormatJSONL)
+	producer := NewProducer(c.Int("workers"), parseHeaders(c.StringSlice("header")), nil, limiter, fetcher, false)
+	consumer := NewConsumer(c.Bool("status-code-only"), log.StandardLogger(), excludes, matchers, parseHeaderRules(c),
+		parseComparator(c), recorders...)
+	New(reader, producer, consumer).Run(context.Background())
+
+	summary.Print(os.Stdout)
+
+	return outputs.write()
+}
+
+func isLiveHost(s string) bool {
+	u, err := url.Parse(s)
+
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https")
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/ratelimit"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.Method == http.MethodPost {
+				b, _ := ioutil.ReadAll(r.Body)
+				_, _ = w.Write(b)
+				return
+			}
+			if r.URL.Path == "/text" {
+				_, _ = w.Write([]byte("plain text"))
+				return
+			}
+			_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+		}),
+	)
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	snapshot, err := CreateSnapshot(dir, newTestLogger())
+	assert.NoError(t, err)
+
+	input := `{"path":"/v1/cards?b=2&a=1"}
+{"method":"POST","path":"/v1/search","body":{"q":"visa"}}
+{"method":"POST","path":"/v1/search","body":{"q": "a<b",  "limit": 10}}
+{"path":"/text"}`
+	reader := NewReader(strings.NewReader(input), []string{server.URL}, FormatJSONL)
+	producer := NewProducer(1, nil, nil, ratelimit.NewUnlimited(), NewHTTPClient(), false)
+	New(reader, producer, snapshot).Run(context.Background())
+	assert.NoError(t, snapshot.Close())
+
+	recorded, failed := snapshot.Counts()
+	assert.Equal(t, 4, recorded)
+	assert.Equal(t, 0, failed)
+
+	// Comparing the snapshot against the live host reads the requests back from its index.
+	index, err := OpenSnapshotIndex(dir)
+	assert.NoError(t, err)
+	defer index.Close()
+
+	fetcher := &routingFetcher{
+		fetchers: map[string]Fetcher{leftSnapshotHost: NewSnapshotFetcher(dir)},
+		fallback: NewHTTPClient(),
+	}
+	spy := new(recorderSpy)
+	reader = NewReader(index, []string{"http://" + leftSnapshotHost, server.URL}, FormatJSONL)
+	producer = NewProducer(1, nil, nil, ratelimit.NewUnlimited(), fetcher, false)
+	New(reader, producer, NewConsumer(true, newTestLogger(), nil, nil, nil, NewComparator(), spy)).Run(context.Background())
+
+	assert.Len(t, spy.results, 4)
+	for _, r := range spy.results {
+		assert.Equal(t, VerdictEqual, r.Verdict, r.RelURL)
+		assert.Equal(t, "application/json", r.Hosts[0].Header.Get("Content-Type"))
+		assert.Equal(t, r.Hosts[1].Body, r.Hosts[0].Body)
+	}
+}
+
+func TestSnapshotFetcherNotFound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewSnapshotFetcher(dir).Fetch(context.Background(), Request{URL: "http://left.snapshot/v1/cards"})
+	assert.EqualError(t, err, "GET /v1/cards not found in snapshot "+dir)
+}
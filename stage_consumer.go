@@ -1,78 +1,291 @@
 package main
 
 import (
+	"encoding/json"
+
 	"github.com/sirupsen/logrus"
 )
 
+// Verdict is the outcome of comparing a HostsPair.
+type Verdict string
+
+const (
+	VerdictEqual      Verdict = "equal"
+	VerdictStatusDiff Verdict = "status-diff"
+	VerdictBodyDiff   Verdict = "body-diff"
+	VerdictHeaderDiff Verdict = "header-diff"
+	VerdictError      Verdict = "error"
+)
+
+// verdicts lists every Verdict in the order they are reported.
+var verdicts = []Verdict{VerdictEqual, VerdictStatusDiff, VerdictBodyDiff, VerdictHeaderDiff, VerdictError}
+
+// Result holds the outcome of comparing a HostsPair.
+// Its Verdict is the worst one found among all the comparisons.
+type Result struct {
+	// Line is the number of the line of the file the request was read from, if any.
+	Line   int
+	RelURL string
+	// Method, Headers and Payload describe the request made to every host, as in the HostsPair.
+	Method      string
+	Headers     map[string]string
+	Payload     []byte
+	Verdict     Verdict
+	Hosts       []Host
+	Comparisons []Comparison
+	Errors      []error
+	// Noise lists the paths ignored for being non deterministic on the baseline host.
+	Noise []string
+}
+
+// Comparison is the outcome of comparing two hosts of a HostsPair, identified by their index.
+type Comparison struct {
+	Left, Right int
+	Verdict     Verdict
+	Diffs       []Difference
+	// Headers holds the differences between the compared response headers, using the header name as path.
+	Headers []Difference
+}
+
+// Recorder is notified with the Result of every HostsPair consumed.
+type Recorder interface {
+	Record(r Result)
+}
+
 type consumer struct {
 	statusCodeOnly bool
-	bar            *ProgressBar
 	log            *logrus.Logger
-	exclude        string
+	excludes       []ExclusionRule
+	matchers       []MatcherRule
+	headers        *HeaderRules
+	comparator     *Comparator
+	recorders      []Recorder
+	noise          *noiseDetector
 }
 
-func NewConsumer(statusCodeOnly bool, bar *ProgressBar, log *logrus.Logger, exclude string) Consumer {
+func NewConsumer(statusCodeOnly bool, log *logrus.Logger, excludes []ExclusionRule, matchers []MatcherRule,
+	headers *HeaderRules, comparator *Comparator, recorders ...Recorder) Consumer {
 	return &consumer{
 		statusCodeOnly: statusCodeOnly,
-		bar:            bar,
 		log:            log,
-		exclude:        exclude,
+		excludes:       excludes,
+		matchers:       matchers,
+		headers:        headers,
+		comparator:     comparator,
+		recorders:      recorders,
+		noise:          newNoiseDetector(),
 	}
 }
 
 func (c *consumer) Consume(val HostsPair) {
+	r := c.compare(val)
+	for _, recorder := range c.recorders {
+		recorder.Record(r)
+	}
+}
+
+func (c *consumer) compare(val HostsPair) Result {
+	r := Result{
+		Line:    val.Line,
+		RelURL:  val.RelURL,
+		Method:  val.Method,
+		Headers: val.Headers,
+		Payload: val.Payload,
+		Hosts:   val.Hosts,
+	}
+
 	if val.HasErrors() {
-		c.bar.IncrementError()
 		for _, v := range val.Errors {
 			c.log.Errorln(v)
 		}
 
-		return
+		return r.withErrors(val.Errors...)
 	}
 
-	if val.EqualStatusCode() && c.statusCodeOnly {
-		c.bar.IncrementOk()
+	// Bodies are unmarshalled lazily since they are not needed when status codes differ.
+	bodies := make([]interface{}, len(val.Hosts))
+	invalid := make([][]invalidValue, len(val.Hosts))
+	parsed := make([]bool, len(val.Hosts))
+	body := func(i int) (interface{}, error) {
+		if parsed[i] {
+			return bodies[i], nil
+		}
 
-		return
+		j, err := unmarshal(val.Hosts[i].Body)
+		if err != nil {
+			c.log.Errorf("could not unmarshal json: url %s, %s: %v", val.RelURL, val.Hosts[i].URL.Host, err)
+
+			return nil, err
+		}
+
+		invalid[i] = c.applyRules(val.RelURL, j, i > 0)
+		for _, v := range invalid[i] {
+			c.log.Warnf("found invalid value: url %s, %s, path %s, %s does not match %s",
+				val.RelURL, val.Hosts[i].URL.Host, displayPath(v.Path), displayValue(v.Value, false), v.Rule)
+		}
+		bodies[i] = j
+		parsed[i] = true
+
+		return j, nil
+	}
+
+	if val.Secondary != nil {
+		c.learnNoise(val, body)
 	}
+	r.Noise = c.noise.noise(endpoint(val.RelURL))
+
+	r.Verdict = VerdictEqual
+	for i := 0; i < len(val.Hosts); i++ {
+		for j := i + 1; j < len(val.Hosts); j++ {
+			cmp, err := c.compareHosts(val, i, j, body, invalid)
+			if err != nil {
+				return r.withErrors(err)
+			}
+
+			r.Comparisons = append(r.Comparisons, cmp)
+			if severity(cmp.Verdict) > severity(r.Verdict) {
+				r.Verdict = cmp.Verdict
+			}
+		}
+	}
+
+	return r
+}
+
+func (c *consumer) compareHosts(val HostsPair, i, j int, body func(int) (interface{}, error),
+	invalid [][]invalidValue) (Comparison, error) {
+	left, right := val.Hosts[i], val.Hosts[j]
+	cmp := Comparison{Left: i, Right: j}
 
-	if !val.EqualStatusCode() {
-		c.bar.IncrementError()
+	if left.StatusCode != right.StatusCode {
 		c.log.Warnf("found status code diff: url %s, %s: %d - %s: %d",
-			val.RelURL, val.Left.URL.Host, val.Left.StatusCode, val.Right.URL.Host, val.Right.StatusCode)
+			val.RelURL, left.URL.Host, left.StatusCode, right.URL.Host, right.StatusCode)
+		cmp.Verdict = VerdictStatusDiff
 
-		return
+		return cmp, nil
+	}
+
+	cmp.Headers = c.headers.Diff(left.Header, right.Header)
+	for _, d := range cmp.Headers {
+		c.log.Warnf("header diff: url %s, header %s, %s, %s: %s - %s: %s",
+			val.RelURL, d.Path, d.Type,
+			left.URL.Host, displayHeader(d.Left, d.Type == MissingLeft),
+			right.URL.Host, displayHeader(d.Right, d.Type == MissingRight))
+	}
+
+	cmp.Verdict = VerdictEqual
+	if len(cmp.Headers) > 0 {
+		cmp.Verdict = VerdictHeaderDiff
+	}
+
+	if c.statusCodeOnly {
+		return cmp, nil
+	}
+
+	leftJSON, err := body(i)
+	if err != nil {
+		return cmp, err
 	}
 
-	leftJSON, err := unmarshal(val.Left.Body)
+	rightJSON, err := body(j)
 	if err != nil {
-		c.bar.IncrementError()
-		c.log.Errorf("could not unmarshal json: url %s: %v", val.RelURL, err)
+		return cmp, err
+	}
+
+	var diffs []Difference
+	for _, v := range invalid[i] {
+		diffs = append(diffs, Difference{Type: InvalidLeft, Path: v.Path, Left: v.Value})
+	}
+	for _, v := range invalid[j] {
+		diffs = append(diffs, Difference{Type: InvalidRight, Path: v.Path, Right: v.Value})
+	}
+	diffs = append(diffs, c.noise.filter(endpoint(val.RelURL), c.comparator.Diff(leftJSON, rightJSON))...)
+
+	if len(diffs) > 0 {
+		c.log.Warnf("found json diff: url %s, %s - %s, %d differences", val.RelURL, left.URL.Host, right.URL.Host, len(diffs))
+		for _, d := range diffs {
+			if d.Type == InvalidLeft || d.Type == InvalidRight {
+				// Already logged along with the rule when the body was parsed.
+				continue
+			}
+			c.log.Warnf("json diff: url %s, path %s, %s, %s: %s - %s: %s",
+				val.RelURL, displayPath(d.Path), d.Type,
+				left.URL.Host, displayValue(d.Left, d.Type == MissingLeft),
+				right.URL.Host, displayValue(d.Right, d.Type == MissingRight))
+		}
+		cmp.Verdict = VerdictBodyDiff
+		cmp.Diffs = diffs
+	}
+
+	return cmp, nil
+}
 
+// learnNoise compares the baseline against its secondary response to learn the noisy paths of the endpoint.
+func (c *consumer) learnNoise(val HostsPair, body func(int) (interface{}, error)) {
+	if val.Secondary.StatusCode != val.Hosts[0].StatusCode || c.statusCodeOnly {
 		return
 	}
 
-	rightJSON, err := unmarshal(val.Right.Body)
+	baseline, err := body(0)
 	if err != nil {
-		c.bar.IncrementError()
-		c.log.Errorf("could not unmarshal json: url %s: %v", val.RelURL, err)
+		return
+	}
 
+	secondary, err := unmarshal(val.Secondary.Body)
+	if err != nil {
 		return
 	}
 
-	if c.exclude != "" {
-		Remove(leftJSON, c.exclude)
-		Remove(rightJSON, c.exclude)
+	c.applyRules(val.RelURL, secondary, false)
+
+	e := endpoint(val.RelURL)
+	for _, p := range c.noise.learn(e, c.comparator.Diff(baseline, secondary)) {
+		c.log.Infof("detected noise: endpoint %s, path %s", e, displayPath(p))
 	}
+}
 
-	if !Equal(leftJSON, rightJSON) {
-		c.bar.IncrementError()
-		c.log.Warnf("found json diff: url %s", val.RelURL)
+// applyRules removes the excluded values of a body and replaces the matched ones with their placeholder,
+// returning the values which do not satisfy their matcher. Rules only for the right side are not validated
+// unless candidate is set.
+func (c *consumer) applyRules(relURL string, j interface{}, candidate bool) []invalidValue {
+	for _, rule := range c.excludes {
+		if rule.Matches(relURL) {
+			Remove(j, rule.Path)
+		}
+	}
 
-		return
+	var invalid []invalidValue
+	for _, rule := range c.matchers {
+		if rule.Matches(relURL) {
+			invalid = append(invalid, rule.apply(j, candidate || !rule.RightOnly)...)
+		}
 	}
 
-	c.bar.IncrementOk()
+	return invalid
+}
+
+// severity ranks verdicts so the worst one of all the comparisons can be reported.
+func severity(v Verdict) int {
+	switch v {
+	case VerdictError:
+		return 4
+	case VerdictStatusDiff:
+		return 3
+	case VerdictBodyDiff:
+		return 2
+	case VerdictHeaderDiff:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (r Result) withErrors(errs ...error) Result {
+	r.Verdict = VerdictError
+	r.Comparisons = nil
+	r.Errors = append(r.Errors, errs...)
+
+	return r
 }
 
 func unmarshal(b []byte) (interface{}, error) {
@@ -83,3 +296,32 @@ func unmarshal(b []byte) (interface{}, error) {
 
 	return j, nil
 }
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+
+	return path
+}
+
+func displayHeader(v interface{}, missing bool) string {
+	if missing {
+		return "<missing>"
+	}
+
+	return v.(string)
+}
+
+func displayValue(v interface{}, missing bool) string {
+	if missing {
+		return "<missing>"
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(b)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorderSpy struct {
+	results []Result
+}
+
+func (r *recorderSpy) Record(result Result) {
+	r.results = append(r.results, result)
+}
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	return logger
+}
+
+func makeHost(name string, status int, body string) Host {
+	return Host{StatusCode: status, Body: []byte(body), URL: &url.URL{Scheme: "http", Host: name, Path: "/v1/cards"}}
+}
+
+func makeHostsPair(leftStatus int, leftBody string, rightStatus int, rightBody string) HostsPair {
+	return HostsPair{
+		RelURL: "/v1/cards",
+		Hosts:  []Host{makeHost("host1.com", leftStatus, leftBody), makeHost("host2.com", rightStatus, rightBody)},
+	}
+}
+
+func countDiffs(r Result) int {
+	var n int
+	for _, cmp := range r.Comparisons {
+		n += len(cmp.Diffs)
+	}
+
+	return n
+}
+
+func TestConsumeVerdicts(t *testing.T) {
+	errored := makeHostsPair(0, "", 200, `{}`)
+	errored.Errors = []error{errors.New("connection refused")}
+
+	tests := []struct {
+		name           string
+		statusCodeOnly bool
+		pair           HostsPair
+		want           Verdict
+		diffs          int
+	}{
+		{name: "equal", pair: makeHostsPair(200, `{"a":1}`, 200, `{"a":1}`), want: VerdictEqual},
+		{name: "status diff", pair: makeHostsPair(200, `{}`, 404, `{}`), want: VerdictStatusDiff},
+		{name: "body diff", pair: makeHostsPair(200, `{"a":1,"b":2}`, 200, `{"a":2}`), want: VerdictBodyDiff, diffs: 2},
+		{name: "status code only", statusCodeOnly: true, pair: makeHostsPair(200, `{"a":1}`, 200, `{"a":2}`), want: VerdictEqual},
+		{name: "invalid json", pair: makeHostsPair(200, `{`, 200, `{}`), want: VerdictError},
+		{name: "fetch error", pair: errored, want: VerdictError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spy := new(recorderSpy)
+			c := NewConsumer(tt.statusCodeOnly, newTestLogger(), nil, nil, nil, NewComparator(), spy)
+			c.Consume(tt.pair)
+
+			assert.Len(t, spy.results, 1)
+			assert.Equal(t, tt.want, spy.results[0].Verdict)
+			assert.Equal(t, tt.diffs, countDiffs(spy.results[0]))
+		})
+	}
+}
+
+func TestResultWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewResultWriter(&buf)
+	c := NewConsumer(false, newTestLogger(), nil, nil, nil, NewComparator(), w)
+
+	c.Consume(makeHostsPair(200, `{"name":"Tom","alias":null}`, 200, `{"name":"Roger","alias":"Rog"}`))
+
+	assert.NoError(t, w.Err())
+	// A null value is written as such rather than omitted, as is the one missing on either side.
+	assert.JSONEq(t, `{"rel_url":"/v1/cards","verdict":"body-diff",`+
+		`"hosts":[{"url":"http://host1.com/v1/cards","status_code":200,"elapsed_ms":0},`+
+		`{"url":"http://host2.com/v1/cards","status_code":200,"elapsed_ms":0}],`+
+		`"comparisons":[{"left":0,"right":1,"verdict":"body-diff",`+
+		`"diffs":[{"path":"alias","type":"mismatch","left":null,"right":"Rog"},`+
+		`{"path":"name","type":"mismatch","left":"Tom","right":"Roger"}]}]}`, buf.String())
+}
+
+func TestConsumeWithExclusionRules(t *testing.T) {
+	global, _ := ParseExclusionRule("date_created")
+	scoped, _ := ParseExclusionRule("/v1/cards*:id")
+	other, _ := ParseExclusionRule("/v1/payments*:name")
+
+	spy := new(recorderSpy)
+	c := NewConsumer(false, newTestLogger(), []ExclusionRule{global, scoped, other}, nil, nil, NewComparator(), spy)
+	c.Consume(makeHostsPair(200, `{"id":1,"name":"a","date_created":"2020"}`, 200, `{"id":2,"name":"b","date_created":"2021"}`))
+
+	assert.Equal(t, VerdictBodyDiff, spy.results[0].Verdict)
+	assert.Equal(t, []Difference{{Type: ValueMismatch, Path: "name", Left: "a", Right: "b"}}, spy.results[0].Comparisons[0].Diffs)
+}
+
+func TestConsumeWithMatcherRules(t *testing.T) {
+	created, _ := ParseMatcherRule("created_at=iso8601")
+	id, _ := ParseMatcherRule("id=right:regex:^card-[0-9]+$")
+	kind, _ := ParseMatcherRule("kind=type")
+
+	spy := new(recorderSpy)
+	c := NewConsumer(false, newTestLogger(), nil, []MatcherRule{created, id, kind}, nil, NewComparator(), spy)
+	c.Consume(makeHostsPair(200, `{"id":1,"created_at":"2020-01-02","kind":"a"}`, 200, `{"id":"card-2","created_at":"2021-03-04T05:06:07Z","kind":"b"}`))
+	c.Consume(makeHostsPair(200, `{"id":1,"created_at":"yesterday","kind":"a"}`, 200, `{"id":"2","created_at":"2021-03-04","kind":1}`))
+
+	assert.Equal(t, VerdictEqual, spy.results[0].Verdict)
+	assert.Equal(t, VerdictBodyDiff, spy.results[1].Verdict)
+	assert.Equal(t, []Difference{
+		{Type: InvalidLeft, Path: "created_at", Left: "yesterday"},
+		{Type: InvalidRight, Path: "id", Right: "2"},
+		{Type: ValueMismatch, Path: "kind", Left: "<string>", Right: "<number>"},
+	}, spy.results[1].Comparisons[0].Diffs)
+}
+
+func TestConsumeWithHeaderRules(t *testing.T) {
+	spy := new(recorderSpy)
+	c := NewConsumer(false, newTestLogger(), nil, nil, NewHeaderRules(false, []string{"Cache-Control"}, nil), NewComparator(), spy)
+
+	pair := makeHostsPair(200, `{"a":1}`, 200, `{"a":1}`)
+	pair.Hosts[0].Header = http.Header{"Cache-Control": {"max-age=60"}, "Date": {"1"}}
+	pair.Hosts[1].Header = http.Header{"Cache-Control": {"no-cache"}, "Date": {"2"}}
+	c.Consume(pair)
+
+	pair = makeHostsPair(200, `{"a":1}`, 200, `{"a":2}`)
+	pair.Hosts[0].Header = http.Header{"Cache-Control": {"max-age=60"}}
+	c.Consume(pair)
+
+	headers := []Difference{{Type: ValueMismatch, Path: "Cache-Control", Left: "max-age=60", Right: "no-cache"}}
+	assert.Equal(t, VerdictHeaderDiff, spy.results[0].Verdict)
+	assert.Equal(t, headers, spy.results[0].Comparisons[0].Headers)
+	assert.Empty(t, spy.results[0].Comparisons[0].Diffs)
+
+	// Body differences are worse than header ones.
+	assert.Equal(t, VerdictBodyDiff, spy.results[1].Verdict)
+	assert.Equal(t, []Difference{{Type: MissingRight, Path: "Cache-Control", Left: "max-age=60"}}, spy.results[1].Comparisons[0].Headers)
+}
+
+func TestConsumeMultipleHosts(t *testing.T) {
+	spy := new(recorderSpy)
+	c := NewConsumer(false, newTestLogger(), nil, nil, nil, NewComparator(), spy)
+	c.Consume(HostsPair{
+		RelURL: "/v1/cards",
+		Hosts: []Host{
+			makeHost("baseline.com", 200, `{"a":1}`),
+			makeHost("candidate1.com", 200, `{"a":1}`),
+			makeHost("candidate2.com", 200, `{"a":2}`),
+			makeHost("candidate3.com", 500, `<html></html>`),
+		},
+	})
+
+	r := spy.results[0]
+	assert.Equal(t, VerdictStatusDiff, r.Verdict)
+	assert.Len(t, r.Comparisons, 6)
+
+	verdicts := make(map[[2]int]Verdict, len(r.Comparisons))
+	for _, cmp := range r.Comparisons {
+		verdicts[[2]int{cmp.Left, cmp.Right}] = cmp.Verdict
+	}
+
+	assert.Equal(t, map[[2]int]Verdict{
+		{0, 1}: VerdictEqual,
+		{0, 2}: VerdictBodyDiff,
+		{0, 3}: VerdictStatusDiff,
+		{1, 2}: VerdictBodyDiff,
+		{1, 3}: VerdictStatusDiff,
+		{2, 3}: VerdictStatusDiff,
+	}, verdicts)
+}
+
+func TestConsumeIgnoresNoise(t *testing.T) {
+	spy := new(recorderSpy)
+	c := NewConsumer(false, newTestLogger(), nil, nil, nil, NewComparator(), spy)
+
+	pair := makeHostsPair(200, `{"id":1,"token":"a","items":[{"trace":"x","n":1}]}`, 200, `{"id":2,"token":"b","items":[{"trace":"y","n":1}]}`)
+	secondary := makeHost("host1.com", 200, `{"id":1,"token":"c","items":[{"trace":"z","n":1}]}`)
+	pair.Secondary = &secondary
+	c.Consume(pair)
+
+	// Noise learned on a previous call to the same endpoint is also ignored.
+	pair = makeHostsPair(200, `{"id":3,"token":"a"}`, 200, `{"id":3,"token":"b"}`)
+	pair.RelURL = "/v1/cards?id=3"
+	c.Consume(pair)
+
+	assert.Equal(t, VerdictBodyDiff, spy.results[0].Verdict)
+	assert.Equal(t, []Difference{{Type: ValueMismatch, Path: "id", Left: json.Number("1"), Right: json.Number("2")}}, spy.results[0].Comparisons[0].Diffs)
+	assert.Equal(t, []string{"items.#.trace", "token"}, spy.results[0].Noise)
+	assert.Equal(t, VerdictEqual, spy.results[1].Verdict)
+	assert.Equal(t, []string{"items.#.trace", "token"}, spy.results[1].Noise)
+}
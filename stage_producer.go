@@ -1,24 +1,34 @@
 package main
 
 import (
+	"context"
+	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"go.uber.org/ratelimit"
 )
 
 type Fetcher interface {
-	Fetch(url string, headers map[string]string) (*Response, error)
+	Fetch(ctx context.Context, req Request) (*Response, error)
 }
 
+// HostsPair holds the responses of every host for the same rel url.
+// The first host is the baseline against which the rest of them are compared.
 type HostsPair struct {
-	RelURL      string
-	Errors      []error
-	Left, Right Host
-}
-
-func (h HostsPair) EqualStatusCode() bool {
-	return h.Left.StatusCode == h.Right.StatusCode
+	// Line is the number of the line of the file the request was read from, if any.
+	Line   int
+	RelURL string
+	// Method, Headers and Payload describe the request made to every host, with Headers
+	// holding only the ones specified for this rel url.
+	Method  string
+	Headers map[string]string
+	Payload []byte
+	Errors  []error
+	Hosts   []Host
+	// Secondary is a second response of the baseline host used to detect noise, if enabled.
+	Secondary *Host
 }
 
 func (h HostsPair) HasErrors() bool {
@@ -27,19 +37,28 @@ func (h HostsPair) HasErrors() bool {
 
 type Host struct {
 	StatusCode int
+	Header     http.Header
 	Body       []byte
 	URL        *url.URL
 	Error      error
+	// Elapsed is the time it took to fetch the response, including retries.
+	Elapsed time.Duration
+	// TTFB is the time to the first byte of the response, if measured by the Fetcher.
+	TTFB time.Duration
+	// Latency is the time it took the last attempt to fetch the response, if measured by the Fetcher.
+	Latency time.Duration
 }
 
 type producer struct {
 	concurrency int
 	headers     map[string]string
+	hosts       []HostOptions
 	limiter     ratelimit.Limiter
 	fetcher     Fetcher
+	detectNoise bool
 }
 
-func (p *producer) Produce(in <-chan URLPair) <-chan HostsPair {
+func (p *producer) Produce(ctx context.Context, in <-chan URLPair) <-chan HostsPair {
 	stream := make(chan HostsPair)
 	go func() {
 		defer close(stream)
@@ -51,8 +70,21 @@ func (p *producer) Produce(in <-chan URLPair) <-chan HostsPair {
 			go func() {
 				defer wg.Done()
 				for val := range in {
-					p.limiter.Take()
-					stream <- p.produce(val)
+					if err := take(ctx, p.limiter); err != nil {
+						return
+					}
+
+					// Pairs fetched while cancelling are discarded, since their requests were interrupted.
+					pair := p.produce(ctx, val)
+					if ctx.Err() != nil {
+						return
+					}
+
+					select {
+					case stream <- pair:
+					case <-ctx.Done():
+						return
+					}
 				}
 			}()
 		}
@@ -62,50 +94,128 @@ func (p *producer) Produce(in <-chan URLPair) <-chan HostsPair {
 	return stream
 }
 
-func NewProducer(concurrency int, headers map[string]string, limiter ratelimit.Limiter, fetcher Fetcher) Producer {
+// NewProducer returns a Producer that fetches every host concurrently, applying the options of each host, if any.
+// If detectNoise is set, the baseline host is fetched twice to find its non deterministic fields,
+// unless the request method is not a safe one.
+func NewProducer(concurrency int, headers map[string]string, hosts []HostOptions, limiter ratelimit.Limiter,
+	fetcher Fetcher, detectNoise bool) Producer {
 	return &producer{
 		concurrency: concurrency,
 		headers:     headers,
+		hosts:       hosts,
 		limiter:     limiter,
 		fetcher:     fetcher,
+		detectNoise: detectNoise,
 	}
 }
 
-func (p *producer) produce(u URLPair) HostsPair {
-	work := func(u URL) <-chan Host {
+func (p *producer) produce(ctx context.Context, u URLPair) HostsPair {
+	work := func(i int, u URL, req Request) <-chan Host {
 		ch := make(chan Host, 1)
 		go func() {
 			defer close(ch)
-			ch <- p.fetch(u)
+			ch <- fetchHost(ctx, hostFetcherFor(p.fetcher, p.hosts, i), u, req)
 		}()
 
 		return ch
 	}
 
-	leftCh := work(u.Left)
-	rightCh := work(u.Right)
+	req := Request{
+		Method:  u.Method,
+		Headers: p.mergeHeaders(u.Headers),
+		Body:    u.Body,
+	}
+
+	channels := make([]<-chan Host, len(u.URLs))
+	for i, url := range u.URLs {
+		channels[i] = work(i, url, req)
+	}
 
-	lHost := <-leftCh
-	rHost := <-rightCh
+	// Only safe methods are fetched twice, since the second request must not change the state of the baseline host.
+	var secondary <-chan Host
+	if p.detectNoise && isSafeMethod(req.Method) {
+		secondary = work(0, u.URLs[0], req)
+	}
 
 	response := HostsPair{
-		RelURL: u.RelURL,
-		Left:   lHost,
-		Right:  rHost,
+		Line:    u.Line,
+		RelURL:  u.RelURL,
+		Method:  u.Method,
+		Headers: u.Headers,
+		Payload: u.Body,
+		Hosts:   make([]Host, len(channels)),
 	}
 
-	if lHost.Error != nil {
-		response.Errors = append(response.Errors, lHost.Error)
+	for i, ch := range channels {
+		host := <-ch
+		response.Hosts[i] = host
+
+		if host.Error != nil {
+			response.Errors = append(response.Errors, host.Error)
+		}
 	}
 
-	if rHost.Error != nil {
-		response.Errors = append(response.Errors, rHost.Error)
+	// An error on the secondary response is not reported since it only prevents noise from being detected.
+	if secondary != nil {
+		if host := <-secondary; host.Error == nil {
+			response.Secondary = &host
+		}
 	}
 
 	return response
 }
 
-func (p *producer) fetch(u URL) Host {
+// isSafeMethod reports whether the method does not change the state of the host, being GET the default one.
+func isSafeMethod(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// mergeHeaders returns the producer headers overridden by the ones specified for a single request.
+func (p *producer) mergeHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return p.headers
+	}
+
+	result := make(map[string]string, len(p.headers)+len(headers))
+	for k, v := range p.headers {
+		result[k] = v
+	}
+
+	for k, v := range headers {
+		result[k] = v
+	}
+
+	return result
+}
+
+// take waits until the limiter allows one more request or the context is done, whichever happens first.
+// The limiter cannot be interrupted, so the wait of a cancelled context still takes its slot once it ends.
+func take(ctx context.Context, limiter ratelimit.Limiter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		limiter.Take()
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetchHost fetches the given url measuring the time it takes.
+func fetchHost(ctx context.Context, fetcher Fetcher, u URL, req Request) Host {
 	host := Host{}
 
 	if u.Error != nil {
@@ -114,16 +224,23 @@ func (p *producer) fetch(u URL) Host {
 		return host
 	}
 
-	response, err := p.fetcher.Fetch(u.URL.String(), p.headers)
+	host.URL = u.URL
+	req.URL = u.URL.String()
+
+	start := time.Now()
+	response, err := fetcher.Fetch(ctx, req)
+	host.Elapsed = time.Since(start)
 	if err != nil {
 		host.Error = err
 
 		return host
 	}
 
-	host.URL = u.URL
 	host.Body = response.Body
 	host.StatusCode = response.StatusCode
+	host.Header = response.Header
+	host.TTFB = response.TTFB
+	host.Latency = response.Latency
 
 	return host
 }
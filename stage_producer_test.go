@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/ratelimit"
+)
+
+func TestProduceWithCancel(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}),
+	)
+	defer server.Close()
+
+	in := make(chan URLPair, 3)
+	for i := 0; i < cap(in); i++ {
+		u, _ := joinPath(server.URL, "/v1/cards")
+		in <- URLPair{RelURL: "/v1/cards", URLs: []URL{{URL: u}, {URL: u}}}
+	}
+	close(in)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	// The second request waits a whole second for the limiter, so both the fetch and the wait must be interrupted.
+	p := NewProducer(2, nil, nil, ratelimit.New(1), NewHTTPClient(), false)
+
+	start := time.Now()
+	var produced int
+	for range p.Produce(ctx, in) {
+		produced++
+	}
+
+	assert.Equal(t, 0, produced)
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+}
+
+func TestProduceWithDetectNoise(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	baseline := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests[r.Method]++
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"id":1}`))
+		}),
+	)
+	defer baseline.Close()
+
+	in := make(chan URLPair, 4)
+	for _, method := range []string{"", http.MethodHead, http.MethodPost, http.MethodDelete} {
+		u, _ := joinPath(baseline.URL, "/v1/cards")
+		in <- URLPair{RelURL: "/v1/cards", Method: method, URLs: []URL{{URL: u}, {URL: u}}}
+	}
+	close(in)
+
+	p := NewProducer(1, nil, nil, ratelimit.NewUnlimited(), NewHTTPClient(), true)
+
+	secondaries := make(map[string]bool)
+	for pair := range p.Produce(context.Background(), in) {
+		secondaries[pair.Method] = pair.Secondary != nil
+	}
+
+	assert.Equal(t, map[string]int{http.MethodGet: 3, http.MethodHead: 3, http.MethodPost: 2, http.MethodDelete: 2}, requests)
+	assert.Equal(t, map[string]bool{"": true, http.MethodHead: true, http.MethodPost: false, http.MethodDelete: false}, secondaries)
+}
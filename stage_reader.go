@@ -2,13 +2,37 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
 	"net/url"
+	"strings"
 )
 
+// Supported formats for the file from which targets are read.
+const (
+	// FormatText expects one relative path per line.
+	FormatText = "text"
+	// FormatJSONL expects one json request per line.
+	// eg: {"method":"POST","path":"/v1/payments","headers":{"X-Caller":"1"},"body":{"amount":10}}
+	FormatJSONL = "jsonl"
+)
+
+// maxLineSize is the maximum size of a single line in the targets file.
+// It is large enough to allow request bodies to be inlined in jsonl files.
+const maxLineSize = 1024 * 1024
+
+// URLPair holds the request to be made to every host for the same rel url.
 type URLPair struct {
-	RelURL      string
-	Left, Right URL
+	// Line is the number of the line of the file the request was read from, starting from 1.
+	Line    int
+	RelURL  string
+	Method  string
+	Headers map[string]string
+	Body    []byte
+	URLs    []URL
 }
 
 type URL struct {
@@ -19,31 +43,155 @@ type URL struct {
 type reader struct {
 	reader io.Reader
 	hosts  []string
+	format string
+}
+
+// jsonlRequest is the representation of each line of a jsonl file.
+type jsonlRequest struct {
+	Method  string            `json:"method,omitempty"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
 }
 
-func (r *reader) Read() <-chan URLPair {
+// newJSONLRequest returns the representation of a request made to every host so it can be read again.
+func newJSONLRequest(method, relURL string, headers map[string]string, payload []byte) jsonlRequest {
+	req := jsonlRequest{
+		Method:  method,
+		Path:    relURL,
+		Headers: headers,
+	}
+
+	switch {
+	case len(payload) == 0:
+	case payload[0] != '"' && json.Valid(payload):
+		req.Body = payload
+	default:
+		// Any other body is written as a json string, which is sent as is when read.
+		req.Body, _ = json.Marshal(string(payload))
+	}
+
+	return req
+}
+
+func (r *reader) Read(ctx context.Context) <-chan URLPair {
 	stream := make(chan URLPair)
 	go func() {
 		defer close(stream)
 
-		leftHost := r.hosts[0]
-		rightHost := r.hosts[1]
-		scanner := bufio.NewScanner(r.reader)
-		for scanner.Scan() {
-			text := scanner.Text()
-			leftURL := URL{}
-			leftURL.URL, leftURL.Error = joinPath(leftHost, text)
+		scanner := newScanner(r.reader)
+		line := 1
+		for ; scanner.Scan(); line++ {
+			pair := r.parse(scanner.Text())
+			pair.Line = line
+
+			select {
+			case stream <- pair:
+			case <-ctx.Done():
+				return
+			}
+		}
 
-			rightURL := URL{}
-			rightURL.URL, rightURL.Error = joinPath(rightHost, text)
+		// The rest of the file cannot be read, so the line that failed is reported as an error.
+		if err := scanner.Err(); err != nil {
+			pair := r.errorPair("", fmt.Errorf("could not read line %d: %v", line, err))
+			pair.Line = line
 
-			stream <- URLPair{RelURL: text, Left: leftURL, Right: rightURL}
+			select {
+			case stream <- pair:
+			case <-ctx.Done():
+			}
 		}
 	}()
 
 	return stream
 }
 
+func (r *reader) parse(text string) URLPair {
+	if r.format != FormatJSONL {
+		return r.makeURLPair(URLPair{RelURL: text, Method: http.MethodGet})
+	}
+
+	pair, err := parseJSONL(text)
+	if err != nil {
+		return r.errorPair(text, fmt.Errorf("invalid jsonl request %q: %v", text, err))
+	}
+
+	return r.makeURLPair(pair)
+}
+
+// errorPair returns a pair that cannot be fetched from any of the hosts because of err.
+func (r *reader) errorPair(relURL string, err error) URLPair {
+	pair := URLPair{RelURL: relURL, URLs: make([]URL, len(r.hosts))}
+	for i := range pair.URLs {
+		pair.URLs[i].Error = err
+	}
+
+	return pair
+}
+
+func (r *reader) makeURLPair(pair URLPair) URLPair {
+	pair.URLs = make([]URL, len(r.hosts))
+	for i, host := range r.hosts {
+		pair.URLs[i].URL, pair.URLs[i].Error = joinPath(host, pair.RelURL)
+	}
+
+	return pair
+}
+
+func parseJSONL(text string) (URLPair, error) {
+	var req jsonlRequest
+	if err := json.Unmarshal([]byte(text), &req); err != nil {
+		return URLPair{}, err
+	}
+
+	if req.Path == "" {
+		return URLPair{}, fmt.Errorf("missing path")
+	}
+
+	pair := URLPair{
+		RelURL:  req.Path,
+		Method:  strings.ToUpper(req.Method),
+		Headers: req.Headers,
+	}
+
+	if pair.Method == "" {
+		pair.Method = http.MethodGet
+	}
+
+	body := []byte(req.Body)
+	switch {
+	case len(body) == 0 || string(body) == "null":
+	case body[0] == '"':
+		// A json string is sent as is, allowing non json payloads such as form encoded bodies.
+		var s string
+		if err := json.Unmarshal(body, &s); err != nil {
+			return URLPair{}, err
+		}
+		pair.Body = []byte(s)
+	default:
+		pair.Body = body
+		if !hasHeader(pair.Headers, "Content-Type") {
+			if pair.Headers == nil {
+				pair.Headers = make(map[string]string, 1)
+			}
+			pair.Headers["Content-Type"] = "application/json"
+		}
+	}
+
+	return pair, nil
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func joinPath(host string, relPath string) (*url.URL, error) {
 	u, err := url.Parse(relPath)
 	if err != nil {
@@ -61,9 +209,49 @@ func joinPath(host string, relPath string) (*url.URL, error) {
 	return base.ResolveReference(u), nil
 }
 
-func NewReader(r io.Reader, hosts []string) Reader {
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	return scanner
+}
+
+func NewReader(r io.Reader, hosts []string, format string) Reader {
 	return &reader{
 		reader: r,
 		hosts:  hosts,
+		format: format,
 	}
 }
+
+// NewSkipReader returns a Reader that discards the requests read from the lines for which skip returns true,
+// such as the ones already compared before resuming a run.
+func NewSkipReader(r Reader, skip func(line int) bool) Reader {
+	return &skipReader{reader: r, skip: skip}
+}
+
+type skipReader struct {
+	reader Reader
+	skip   func(line int) bool
+}
+
+func (r *skipReader) Read(ctx context.Context) <-chan URLPair {
+	stream := make(chan URLPair)
+	go func() {
+		defer close(stream)
+
+		for pair := range r.reader.Read(ctx) {
+			if r.skip(pair.Line) {
+				continue
+			}
+
+			select {
+			case stream <- pair:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return stream
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadText(t *testing.T) {
+	r := NewReader(strings.NewReader("/v1/cards?id=1\n/v1/cards?id=2"), []string{"http://host1.com", "http://host2.com"}, FormatText)
+
+	var pairs []URLPair
+	for pair := range r.Read(context.Background()) {
+		pairs = append(pairs, pair)
+	}
+
+	assert.Len(t, pairs, 2)
+	assert.Len(t, pairs[0].URLs, 2)
+	assert.Equal(t, "GET", pairs[0].Method)
+	assert.Equal(t, "http://host1.com/v1/cards?id=1", pairs[0].URLs[0].URL.String())
+	assert.Equal(t, "http://host2.com/v1/cards?id=2", pairs[1].URLs[1].URL.String())
+}
+
+func TestReadJSONL(t *testing.T) {
+	input := `{"method":"post","path":"/v1/payments?site=MLA","headers":{"X-Caller":"1"},"body":{"amount":10}}
+{"path":"/v1/payments/1"}
+{"method":"PUT","path":"/v1/payments/1","body":"status=approved","headers":{"content-type":"application/x-www-form-urlencoded"}}
+not a json`
+	r := NewReader(strings.NewReader(input), []string{"http://host1.com", "http://host2.com"}, FormatJSONL)
+
+	var pairs []URLPair
+	for pair := range r.Read(context.Background()) {
+		pairs = append(pairs, pair)
+	}
+
+	assert.Len(t, pairs, 4)
+
+	assert.Equal(t, "POST", pairs[0].Method)
+	assert.Equal(t, "http://host1.com/v1/payments?site=MLA", pairs[0].URLs[0].URL.String())
+	assert.Equal(t, "http://host2.com/v1/payments?site=MLA", pairs[0].URLs[1].URL.String())
+	assert.Equal(t, []byte(`{"amount":10}`), pairs[0].Body)
+	assert.Equal(t, map[string]string{"X-Caller": "1", "Content-Type": "application/json"}, pairs[0].Headers)
+
+	assert.Equal(t, "GET", pairs[1].Method)
+	assert.Nil(t, pairs[1].Body)
+
+	assert.Equal(t, []byte("status=approved"), pairs[2].Body)
+	assert.Equal(t, map[string]string{"content-type": "application/x-www-form-urlencoded"}, pairs[2].Headers)
+
+	assert.Error(t, pairs[3].URLs[0].Error)
+	assert.Error(t, pairs[3].URLs[1].Error)
+}
+
+func TestReadOversizeLine(t *testing.T) {
+	input := "/v1/cards?id=1\n/v1/cards?id=" + strings.Repeat("2", maxLineSize) + "\n/v1/cards?id=3"
+	r := NewReader(strings.NewReader(input), []string{"http://host1.com", "http://host2.com"}, FormatText)
+
+	var pairs []URLPair
+	for pair := range r.Read(context.Background()) {
+		pairs = append(pairs, pair)
+	}
+
+	// The line that cannot be read is reported as an error, since the rest of the file is not read.
+	assert.Len(t, pairs, 2)
+	assert.NoError(t, pairs[0].URLs[0].Error)
+	assert.Equal(t, 2, pairs[1].Line)
+	assert.EqualError(t, pairs[1].URLs[1].Error, "could not read line 2: bufio.Scanner: token too long")
+
+	_, err := getTotalLines(strings.NewReader(input))
+	assert.EqualError(t, err, "line 2: bufio.Scanner: token too long")
+}
+
+func TestReadMultipleHosts(t *testing.T) {
+	r := NewReader(strings.NewReader("/v1/cards"), []string{"http://host1.com", "http://host2.com", "http://host3.com"}, FormatText)
+
+	pair := <-r.Read(context.Background())
+
+	assert.Len(t, pair.URLs, 3)
+	assert.Equal(t, "http://host3.com/v1/cards", pair.URLs[2].URL.String())
+}
+
+func TestSkipReader(t *testing.T) {
+	r := NewReader(strings.NewReader("/v1/cards/1\n/v1/cards/2\n/v1/cards/3"), []string{"http://host1.com"}, FormatText)
+	r = NewSkipReader(r, func(line int) bool { return line == 2 })
+
+	var relURLs []string
+	for pair := range r.Read(context.Background()) {
+		relURLs = append(relURLs, pair.RelURL)
+	}
+
+	assert.Equal(t, []string{"/v1/cards/1", "/v1/cards/3"}, relURLs)
+}
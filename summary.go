@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Summary is a Recorder that tallies results by verdict.
+type Summary struct {
+	mu     sync.Mutex
+	hosts  []string
+	counts map[Verdict]int
+	total  int
+	// disagreements counts the mismatches between every pair of hosts, identified by their index.
+	disagreements map[[2]int]int
+}
+
+func NewSummary(hosts []string) *Summary {
+	return &Summary{
+		hosts:         hosts,
+		counts:        make(map[Verdict]int, len(verdicts)),
+		disagreements: make(map[[2]int]int),
+	}
+}
+
+func (s *Summary) Record(r Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[r.Verdict]++
+	s.total++
+
+	for _, cmp := range r.Comparisons {
+		if cmp.Verdict != VerdictEqual {
+			s.disagreements[[2]int{cmp.Left, cmp.Right}]++
+		}
+	}
+}
+
+// summaryState is the tally of a Summary as saved in a checkpoint.
+type summaryState struct {
+	Total         int             `json:"total"`
+	Counts        map[Verdict]int `json:"counts"`
+	Disagreements []disagreement  `json:"disagreements,omitempty"`
+}
+
+// disagreement is the number of mismatches between two hosts, identified by their index.
+type disagreement struct {
+	Left  int `json:"left"`
+	Right int `json:"right"`
+	Count int `json:"count"`
+}
+
+// state returns the tally recorded so far.
+func (s *Summary) state() summaryState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := summaryState{Total: s.total, Counts: make(map[Verdict]int, len(s.counts))}
+	for v, count := range s.counts {
+		state.Counts[v] = count
+	}
+
+	for i := 0; i < len(s.hosts); i++ {
+		for j := i + 1; j < len(s.hosts); j++ {
+			if count := s.disagreements[[2]int{i, j}]; count > 0 {
+				state.Disagreements = append(state.Disagreements, disagreement{Left: i, Right: j, Count: count})
+			}
+		}
+	}
+
+	return state
+}
+
+// restore continues the tally from a state saved by a previous run.
+func (s *Summary) restore(state summaryState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.total = state.Total
+	for v, count := range state.Counts {
+		s.counts[v] = count
+	}
+
+	for _, d := range state.Disagreements {
+		s.disagreements[[2]int{d.Left, d.Right}] = d.Count
+	}
+}
+
+// Total returns the number of results recorded.
+func (s *Summary) Total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.total
+}
+
+// Count returns the number of results recorded with any of the given verdicts.
+func (s *Summary) Count(v ...Verdict) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int
+	for _, verdict := range v {
+		count += s.counts[verdict]
+	}
+
+	return count
+}
+
+// Mismatches returns the number of results in which both hosts responded differently.
+func (s *Summary) Mismatches() int {
+	return s.Count(VerdictStatusDiff, VerdictBodyDiff, VerdictHeaderDiff)
+}
+
+// Errors returns the number of results that could not be compared.
+func (s *Summary) Errors() int {
+	return s.Count(VerdictError)
+}
+
+// Print writes the tally of every verdict to w.
+func (s *Summary) Print(w io.Writer) {
+	total := s.Total()
+	fmt.Fprintf(w, "%-12s %d\n", "total", total)
+	for _, v := range verdicts {
+		count := s.Count(v)
+		fmt.Fprintf(w, "%-12s %d (%.2f%%)\n", v, count, percentage(count, total))
+	}
+
+	if len(s.hosts) <= 2 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintln(w, "mismatches between hosts")
+	for i := 0; i < len(s.hosts); i++ {
+		for j := i + 1; j < len(s.hosts); j++ {
+			count := s.disagreements[[2]int{i, j}]
+			fmt.Fprintf(w, "  %s - %s: %d (%.2f%%)\n", s.hosts[i], s.hosts[j], count, percentage(count, total))
+		}
+	}
+}
+
+func percentage(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(count) * 100 / float64(total)
+}
+
+// Threshold is the maximum number of results allowed for a given verdict,
+// expressed either as an absolute count or as a percentage of the total.
+type Threshold struct {
+	value   float64
+	percent bool
+}
+
+// ParseThreshold parses an absolute count such as "10" or a percentage such as "2.5%".
+func ParseThreshold(s string) (Threshold, error) {
+	t := Threshold{}
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		t.percent = true
+		s = strings.TrimSuffix(s, "%")
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 {
+		return Threshold{}, fmt.Errorf("invalid threshold %q", s)
+	}
+	t.value = v
+
+	return t, nil
+}
+
+// Exceeded reports whether count is above the threshold.
+func (t Threshold) Exceeded(count, total int) bool {
+	if t.percent {
+		return percentage(count, total) > t.value
+	}
+
+	return float64(count) > t.value
+}
+
+func (t Threshold) String() string {
+	v := strconv.FormatFloat(t.value, 'f', -1, 64)
+	if t.percent {
+		return v + "%"
+	}
+
+	return v
+}
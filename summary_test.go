@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreshold(t *testing.T) {
+	tests := []struct {
+		threshold string
+		count     int
+		total     int
+		exceeded  bool
+	}{
+		{threshold: "0", count: 0, total: 10, exceeded: false},
+		{threshold: "0", count: 1, total: 10, exceeded: true},
+		{threshold: "5", count: 5, total: 10, exceeded: false},
+		{threshold: "10%", count: 1, total: 10, exceeded: false},
+		{threshold: "10%", count: 2, total: 10, exceeded: true},
+		{threshold: "2.5%", count: 3, total: 100, exceeded: true},
+		{threshold: "0%", count: 0, total: 0, exceeded: false},
+	}
+
+	for _, tt := range tests {
+		th, err := ParseThreshold(tt.threshold)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.exceeded, th.Exceeded(tt.count, tt.total), "%s with %d of %d", tt.threshold, tt.count, tt.total)
+	}
+}
+
+func TestParseInvalidThreshold(t *testing.T) {
+	for _, s := range []string{"", "abc", "-1", "%"} {
+		_, err := ParseThreshold(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	s := NewSummary([]string{"http://host1.com", "http://host2.com"})
+	for _, v := range []Verdict{VerdictEqual, VerdictEqual, VerdictBodyDiff, VerdictStatusDiff, VerdictError} {
+		s.Record(Result{Verdict: v})
+	}
+
+	assert.Equal(t, 5, s.Total())
+	assert.Equal(t, 2, s.Mismatches())
+	assert.Equal(t, 1, s.Errors())
+}
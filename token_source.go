@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is refreshed, so it does not expire while a request is in flight.
+// Tokens living less than twice as long are refreshed halfway through their lifetime instead, so they are still cached.
+const tokenExpiryDelta = 30 * time.Second
+
+// TokenSource obtains access tokens from an OAuth2 client credentials endpoint, caching them until shortly before
+// they expire. It is safe for concurrent use and only one token is requested at a time.
+type TokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	httpClient   *http.Client
+	now          func() time.Time
+
+	mu    sync.Mutex
+	token string
+	// refresh is when the cached token stops being used, being zero if it does not expire.
+	refresh time.Time
+}
+
+// tokenResponse is the successful response of a token endpoint as described in RFC 6749, section 5.1.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func NewTokenSource(tokenURL, clientID, clientSecret string, scopes []string) *TokenSource {
+	return &TokenSource{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		httpClient:   &http.Client{Timeout: DefaultTimeout},
+		now:          time.Now,
+	}
+}
+
+// Token returns the cached token, requesting a new one if there is none or it is about to expire.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.refresh.IsZero() || s.now().Before(s.refresh)) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.request(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = token
+	s.refresh = time.Time{}
+	if expiresIn > 0 {
+		delta := tokenExpiryDelta
+		if expiresIn < 2*delta {
+			delta = expiresIn / 2
+		}
+		s.refresh = s.now().Add(expiresIn - delta)
+	}
+
+	return s.token, nil
+}
+
+// Invalidate discards the given token, if it is still the cached one, so the next call to Token requests a new one.
+// It is meant to be called when a host rejects the token before its expiry.
+func (s *TokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+func (s *TokenSource) request(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.scopes) > 0 {
+		form.Set("scope", strings.Join(s.scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.clientID), url.QueryEscape(s.clientSecret))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("could not request oauth2 token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", 0, fmt.Errorf("could not read oauth2 token: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("could not request oauth2 token: status code %d: %s", resp.StatusCode, body)
+	}
+
+	var t tokenResponse
+	if err := json.Unmarshal(body, &t); err != nil {
+		return "", 0, fmt.Errorf("could not parse oauth2 token: %v", err)
+	}
+
+	if t.AccessToken == "" {
+		return "", 0, fmt.Errorf("could not parse oauth2 token: missing access_token")
+	}
+
+	return t.AccessToken, time.Duration(t.ExpiresIn) * time.Second, nil
+}
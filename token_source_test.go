@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTokenServer returns a stand-in token endpoint which issues a new token on every request.
+func newTokenServer(t *testing.T, expiresIn int, issued *int32) *httptest.Server {
+	return httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "read write", r.PostForm.Get("scope"))
+
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != "gomparator" || secret != "s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+
+				return
+			}
+
+			n := atomic.AddInt32(issued, 1)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, expiresIn)
+		}),
+	)
+}
+
+func TestTokenSource(t *testing.T) {
+	var issued int32
+	server := newTokenServer(t, 3600, &issued)
+	defer server.Close()
+
+	now := time.Now()
+	s := NewTokenSource(server.URL, "gomparator", "s3cret", []string{"read", "write"})
+	s.now = func() time.Time { return now }
+
+	token, err := s.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	token, err = s.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The token is refreshed shortly before it expires.
+	now = now.Add(time.Hour - tokenExpiryDelta)
+	token, err = s.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+
+	// Invalidating a token which is no longer cached does not discard the current one.
+	s.Invalidate("token-1")
+	token, _ = s.Token(context.Background())
+	assert.Equal(t, "token-2", token)
+
+	s.Invalidate("token-2")
+	token, _ = s.Token(context.Background())
+	assert.Equal(t, "token-3", token)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&issued))
+}
+
+func TestTokenSourceShortLived(t *testing.T) {
+	var issued int32
+	server := newTokenServer(t, 10, &issued)
+	defer server.Close()
+
+	now := time.Now()
+	s := NewTokenSource(server.URL, "gomparator", "s3cret", []string{"read", "write"})
+	s.now = func() time.Time { return now }
+
+	token, _ := s.Token(context.Background())
+	assert.Equal(t, "token-1", token)
+
+	// A token expiring sooner than tokenExpiryDelta is still cached, until halfway through its lifetime.
+	now = now.Add(4 * time.Second)
+	token, _ = s.Token(context.Background())
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(time.Second)
+	token, _ = s.Token(context.Background())
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+}
+
+func TestTokenSourceError(t *testing.T) {
+	var issued int32
+	server := newTokenServer(t, 3600, &issued)
+	defer server.Close()
+
+	s := NewTokenSource(server.URL, "gomparator", "wrong", []string{"read", "write"})
+	_, err := s.Token(context.Background())
+	assert.EqualError(t, err, `could not request oauth2 token: status code 401: {"error":"invalid_client"}`)
+}
+
+func TestOAuth2(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(t, 3600, &issued)
+	defer tokenServer.Close()
+
+	// The host revokes the first token it receives so the client must request a new one.
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if auth := r.Header.Get("Authorization"); auth != "Bearer token-2" && auth != "Basic YWRtaW46c2VjcmV0" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}),
+	)
+	defer server.Close()
+
+	tokens := NewTokenSource(tokenServer.URL, "gomparator", "s3cret", []string{"read", "write"})
+	c := NewHTTPClient(OAuth2(tokens))
+
+	res, err := c.Fetch(context.Background(), Request{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+
+	res, err = c.Fetch(context.Background(), Request{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+
+	// Credentials given for the request are sent as is.
+	res, err = c.Fetch(context.Background(), Request{URL: server.URL, Headers: map[string]string{"authorization": "Basic YWRtaW46c2VjcmV0"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 200, res.StatusCode)
+
+	res, err = c.Fetch(context.Background(), Request{URL: server.URL, Headers: map[string]string{"Authorization": "expired"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 401, res.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&issued))
+}